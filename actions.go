@@ -3,7 +3,10 @@ package main
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 )
 
 type LabelPredicate int
@@ -20,21 +23,61 @@ type Action struct {
 	Comment   string
 	Label     string
 	Predicate LabelPredicate
+
+	// When and SkipWhen are compiled `when:`/`skip_when:` DSL
+	// expressions (see predicate.go). Either may be nil if the
+	// action didn't declare one.
+	When     PredicateExpr
+	SkipWhen PredicateExpr
+
+	// CommentTemplate is the compiled form of Comment when it
+	// contains template actions (see comment_template.go). Nil for
+	// static comments.
+	CommentTemplate *template.Template
+
+	// Cooldown overrides Config.Throttle for this action in watch
+	// mode (see watch.go): zero means "use Config.Throttle".
+	Cooldown time.Duration
 }
 
 // Command returns the gh(1) CLI command string to post this action to
 // a PR.
 func (a Action) Command(repo string, prNumber int) string {
-	escaped := strings.ReplaceAll(a.Comment, `"`, `\"`)
+	return a.CommandWithComment(repo, prNumber, a.Comment)
+}
+
+// CommandWithComment returns the gh(1) CLI command string to post
+// comment (typically the result of rendering a.CommentTemplate) to a
+// PR.
+func (a Action) CommandWithComment(repo string, prNumber int, comment string) string {
+	escaped := strings.ReplaceAll(comment, `"`, `\"`)
 	return fmt.Sprintf(`gh pr comment --repo %s %d --body "%s"`, repo, prNumber, escaped)
 }
 
-// FilterActions returns only those actions that should be applied given the PR labels.
-func FilterActions(actions []Action, prLabels []string) []Action {
+// ArgsWithComment returns the argv (minus the "gh" binary itself) that
+// CommandWithComment's string describes, for callers that need to exec
+// gh(1) directly instead of parsing a shell command line. Since these
+// are passed straight to exec.Command with no shell involved, repo,
+// prNumber, and comment need no quoting or escaping here.
+func (a Action) ArgsWithComment(repo string, prNumber int, comment string) []string {
+	return []string{"pr", "comment", "--repo", repo, strconv.Itoa(prNumber), "--body", comment}
+}
+
+// Render returns the comment text to post for pr, executing
+// CommentTemplate against pr's context if one was compiled, or
+// returning Comment verbatim otherwise.
+func (a Action) Render(pr PullRequest, repo string) (string, error) {
+	return RenderComment(a.CommentTemplate, a.Comment, NewCommentContext(pr, repo))
+}
+
+// FilterActions returns only those actions that should be applied to
+// pr, honouring the legacy label predicate plus any `when`/`skip_when`
+// expression.
+func FilterActions(actions []Action, pr PullRequest) []Action {
 	var filtered []Action
 
 	for _, a := range actions {
-		hasLabel := contains(prLabels, a.Label)
+		hasLabel := contains(pr.Labels, a.Label)
 		switch a.Predicate {
 		case PredicateSkipIfLabelExists:
 			if hasLabel {
@@ -45,6 +88,14 @@ func FilterActions(actions []Action, prLabels []string) []Action {
 				continue
 			}
 		}
+
+		if a.SkipWhen != nil && a.SkipWhen.Evaluate(pr) {
+			continue
+		}
+		if a.When != nil && !a.When.Evaluate(pr) {
+			continue
+		}
+
 		filtered = append(filtered, a)
 	}
 
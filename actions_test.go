@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAction_ArgsWithComment(t *testing.T) {
+	a := Action{Comment: "/approve"}
+
+	got := a.ArgsWithComment("owner/repo", 42, "/approve")
+	want := []string{"pr", "comment", "--repo", "owner/repo", "42", "--body", "/approve"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ArgsWithComment() = %v, want %v", got, want)
+	}
+}
+
+// TestAction_ArgsWithComment_ShellMetacharactersAreNotInterpreted
+// guards against a regression back to the sh -c-based execution this
+// replaced: comment text containing shell metacharacters must pass
+// through as a single literal argv element, not be split or
+// interpreted, since ArgsWithComment's result is handed straight to
+// exec.Command with no shell involved.
+func TestAction_ArgsWithComment_ShellMetacharactersAreNotInterpreted(t *testing.T) {
+	a := Action{}
+	malicious := `$(rm -rf /); "; touch pwned; echo "`
+
+	got := a.ArgsWithComment("owner/repo", 1, malicious)
+	want := []string{"pr", "comment", "--repo", "owner/repo", "1", "--body", malicious}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ArgsWithComment() = %v, want %v", got, want)
+	}
+}
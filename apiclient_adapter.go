@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+
+	"github.com/frobware/autoprat/github/apiclient"
+)
+
+// apiBackedClient adapts apiclient.Client (the native go-github
+// implementation) to the GitHubClient interface, so --backend=api can
+// be selected in place of the default gh-backed NewClient without
+// touching fetchAllRepositoryPRsWithSearch or anything downstream of
+// it.
+type apiBackedClient struct {
+	client *apiclient.Client
+}
+
+// newAPIBackedClient constructs a GitHubClient backed directly by the
+// GitHub API instead of the gh CLI, for environments where installing
+// gh is undesirable.
+func newAPIBackedClient(repo string) (GitHubClient, error) {
+	client, err := apiclient.NewClient(repo)
+	if err != nil {
+		return nil, err
+	}
+	return &apiBackedClient{client: client}, nil
+}
+
+func (c *apiBackedClient) Search(ctx context.Context, query string) ([]PullRequest, error) {
+	prs, err := c.client.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		converted := PullRequest{
+			Number:              pr.Number,
+			Title:               pr.Title,
+			HeadRefName:         pr.HeadRefName,
+			CreatedAt:           pr.CreatedAt,
+			Labels:              pr.Labels,
+			AuthorLogin:         pr.AuthorLogin,
+			AuthorType:          pr.AuthorType,
+			URL:                 pr.URL,
+			State:               pr.State,
+			Mergeable:           pr.Mergeable,
+			MergeStateStatus:    pr.MergeStateStatus,
+			IsInMergeQueue:      pr.IsInMergeQueue,
+			IsMergeQueueEnabled: pr.IsMergeQueueEnabled,
+		}
+		for _, check := range pr.StatusCheckRollup.Contexts.Nodes {
+			converted.StatusCheckRollup.Contexts.Nodes = append(converted.StatusCheckRollup.Contexts.Nodes, StatusCheck{
+				Context:    check.Context,
+				Name:       check.Name,
+				State:      check.State,
+				Conclusion: check.Conclusion,
+				DetailsUrl: check.DetailsUrl,
+				TargetUrl:  check.TargetUrl,
+			})
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
@@ -20,12 +20,37 @@ func Parse(availableActions map[string]ActionDefinition, availableTemplates map[
 
 	// Handle version flag before validation
 	showVersion := flagRefs["version"].(*bool)
+	versionFormat := flagRefs["version-format"].(*string)
 	if *showVersion {
-		info := Get()
-		fmt.Printf("autoprat version %s\n", info.Version)
-		fmt.Printf("Built: %s\n", info.BuildTime)
-		fmt.Printf("Go version: %s\n", info.GoVersion)
-		fmt.Printf("Platform: %s\n", info.Platform)
+		if err := Get().Format(os.Stdout, *versionFormat); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --dump-template before validation, same as --version.
+	dumpTemplate := flagRefs["dump-template"].(*bool)
+	if *dumpTemplate {
+		fmt.Print(verboseTemplate)
+		os.Exit(0)
+	}
+
+	// Handle --list-profiles before validation, same as --version.
+	listProfiles := flagRefs["list-profiles"].(*bool)
+	if *listProfiles {
+		profiles, ok, err := loadProfiles()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !ok || len(profiles) == 0 {
+			fmt.Println("No profiles found at", defaultProfilesConfigFile())
+		} else {
+			for _, name := range ListProfileNames(profiles) {
+				fmt.Println(name)
+			}
+		}
 		os.Exit(0)
 	}
 
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// CommentContext is the data made available when rendering an
+// Action's Comment as a text/template, e.g. `comment: "/retest {{
+// .FailingChecks | join \",\" }}"` or `comment: "@{{ .Author }}
+// please rebase; head is {{ .HeadRefName }}"`.
+type CommentContext struct {
+	Number          int
+	Title           string
+	Author          string
+	HeadRefName     string
+	Labels          []string
+	CIStatus        string
+	FailingChecks   []string
+	LastCommentTime string
+	RepoOwner       string
+	RepoName        string
+}
+
+// NewCommentContext builds the template context for pr, which lives
+// in repo ("owner/name").
+func NewCommentContext(pr PullRequest, repo string) CommentContext {
+	owner, name := splitRepo(repo)
+
+	return CommentContext{
+		Number:          pr.Number,
+		Title:           pr.Title,
+		Author:          pr.Author(),
+		HeadRefName:     pr.HeadRefName,
+		Labels:          pr.Labels,
+		CIStatus:        pr.CIStatus(),
+		FailingChecks:   pr.FailingChecks(),
+		LastCommentTime: pr.LastCommentTime(),
+		RepoOwner:       owner,
+		RepoName:        name,
+	}
+}
+
+func splitRepo(repo string) (owner, name string) {
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return repo, ""
+	}
+	return parts[0], parts[1]
+}
+
+// commentTemplateFuncs is the funcmap exposed to Comment templates.
+var commentTemplateFuncs = template.FuncMap{
+	"join": func(sep string, items []string) string {
+		return strings.Join(items, sep)
+	},
+}
+
+// CompileCommentTemplate parses comment as a text/template under
+// name. Plain strings with no template actions are left uncompiled
+// (nil, nil) so static comments pay no rendering cost.
+func CompileCommentTemplate(name, comment string) (*template.Template, error) {
+	if !strings.Contains(comment, "{{") {
+		return nil, nil
+	}
+	return template.New(name).Funcs(commentTemplateFuncs).Parse(comment)
+}
+
+// RenderComment renders tmpl against ctx, or returns fallback
+// verbatim if tmpl is nil (a static, non-templated comment).
+func RenderComment(tmpl *template.Template, fallback string, ctx CommentContext) (string, error) {
+	if tmpl == nil {
+		return fallback, nil
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ctx); err != nil {
+		return "", fmt.Errorf("failed to render comment template: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompileCommentTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "static comment", comment: "/retest", wantNil: true},
+		{name: "templated comment", comment: "/retest {{ .FailingChecks | join \",\" }}", wantNil: false},
+		{name: "invalid template", comment: "{{ .Foo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := CompileCommentTemplate("test", tt.comment)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantNil && tmpl != nil {
+				t.Error("expected nil template for static comment")
+			}
+			if !tt.wantNil && tmpl == nil {
+				t.Error("expected compiled template for templated comment")
+			}
+		})
+	}
+}
+
+func TestRenderComment(t *testing.T) {
+	pr := PullRequest{
+		Number:      42,
+		Title:       "Fix the thing",
+		HeadRefName: "fix-branch",
+		AuthorLogin: "octocat",
+		StatusCheckRollup: StatusCheckRollup{
+			Contexts: struct {
+				Nodes []StatusCheck `json:"nodes"`
+			}{
+				Nodes: []StatusCheck{
+					{Name: "unit-tests", Conclusion: "FAILURE"},
+					{Name: "lint", Conclusion: "SUCCESS"},
+				},
+			},
+		},
+	}
+
+	tmpl, err := CompileCommentTemplate("test", `/retest {{ .FailingChecks | join "," }}`)
+	if err != nil {
+		t.Fatalf("failed to compile template: %v", err)
+	}
+
+	rendered, err := RenderComment(tmpl, "", NewCommentContext(pr, "owner/repo"))
+	if err != nil {
+		t.Fatalf("failed to render comment: %v", err)
+	}
+	if !strings.Contains(rendered, "unit-tests") {
+		t.Errorf("expected rendered comment to mention failing check, got: %q", rendered)
+	}
+	if strings.Contains(rendered, "lint") {
+		t.Errorf("expected rendered comment to omit passing check, got: %q", rendered)
+	}
+}
+
+func TestRenderCommentStaticFallback(t *testing.T) {
+	rendered, err := RenderComment(nil, "/lgtm", NewCommentContext(PullRequest{}, "owner/repo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rendered != "/lgtm" {
+		t.Errorf("expected static fallback, got: %q", rendered)
+	}
+}
+
+func TestActionRender(t *testing.T) {
+	tmpl, err := CompileCommentTemplate("test", "@{{ .Author }} please rebase; head is {{ .HeadRefName }}")
+	if err != nil {
+		t.Fatalf("failed to compile template: %v", err)
+	}
+
+	a := Action{Comment: "unused", CommentTemplate: tmpl}
+	pr := PullRequest{AuthorLogin: "octocat", HeadRefName: "feature-x"}
+
+	rendered, err := a.Render(pr, "owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "@octocat please rebase; head is feature-x"
+	if rendered != want {
+		t.Errorf("got %q, want %q", rendered, want)
+	}
+}
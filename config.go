@@ -9,11 +9,71 @@ type Config struct {
 	Repositories []string
 	ParsedPRs    []PullRequestRef
 	Actions      []Action
-	SearchQuery  string
+	// WorkflowActions holds selected actions that declared a
+	// multi-step `steps:` workflow rather than a single Comment; they
+	// are run via OrderWorkflowSteps/PlanWorkflow instead of
+	// FilterActions.
+	WorkflowActions []ActionDefinition
+	SearchQuery     string
 	// Runtime flags
 	Throttle         time.Duration
 	DebugMode        bool
 	Detailed         bool
 	DetailedWithLogs bool
 	Quiet            bool
+
+	// PrintContext, when true, makes Run return a ContextResult dumping
+	// the rendered CommentContext for each matched PR instead of
+	// posting anything, so users can debug their `comment:` templates.
+	PrintContext bool
+
+	// Output selects the result format: "json" or "ndjson" for
+	// structured output, or "table"/"verbose"/"quiet" to pick a
+	// formatter explicitly (overriding Detailed/Quiet). Empty means
+	// the default tabular/verbose/quiet formatting chosen from those
+	// flags.
+	Output string
+
+	// Template selects a named override template from
+	// ~/.config/autoprat/templates/*.tmpl to render --detailed output
+	// with, in place of the embedded default (see --dump-template).
+	// Empty means the embedded default.
+	Template string
+
+	// Filter, when set, is a compiled --filter expression applied as a
+	// post-filter: PRs for which Evaluate returns false (or an error)
+	// are dropped from the result, after search-query and
+	// ParsedPRs-based filtering.
+	Filter *FilterExpr
+
+	// PartialResults, when true, allows fetchAllRepositoryPRsWithSearch
+	// to return successfully-fetched repositories even if others
+	// failed, instead of discarding everything on the first error.
+	PartialResults bool
+
+	// MaxConcurrency bounds how many repositories are fetched
+	// concurrently. Zero means unbounded (one goroutine per
+	// repository).
+	MaxConcurrency int
+
+	// Backend selects the GitHubClient implementation: "gh" (default)
+	// delegates to the gh CLI; "api" talks to the GitHub API directly
+	// via github/apiclient, for environments without a gh binary.
+	Backend string
+
+	// WatchInterval, when non-zero, switches Run's caller over to
+	// RunWatch: instead of a single pass, the search is repeated on
+	// this interval and only new or cooldown-elapsed matches are
+	// emitted, so autoprat can run unattended as a long-lived process.
+	WatchInterval time.Duration
+
+	// Execute, in watch mode, runs each generated `gh` command by
+	// exec'ing gh(1) directly (no shell) instead of only
+	// printing/emitting it.
+	Execute bool
+
+	// StateFile is where watch mode persists which (PR, action) pairs
+	// it has already posted, and when. Empty means
+	// defaultWatchStateFile().
+	StateFile string
 }
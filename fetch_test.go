@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type stubGitHubClient struct {
+	searchFunc func(ctx context.Context, query string) ([]PullRequest, error)
+}
+
+func (s *stubGitHubClient) Search(ctx context.Context, query string) ([]PullRequest, error) {
+	return s.searchFunc(ctx, query)
+}
+
+func stubFactory(clients map[string]GitHubClient) func(repo string) (GitHubClient, error) {
+	return func(repo string) (GitHubClient, error) {
+		client, ok := clients[repo]
+		if !ok {
+			return nil, fmt.Errorf("no stub client for %s", repo)
+		}
+		return client, nil
+	}
+}
+
+func TestFetchAllRepositoryPRsWithSearch_AllFail(t *testing.T) {
+	clients := map[string]GitHubClient{
+		"owner/a": &stubGitHubClient{searchFunc: func(ctx context.Context, query string) ([]PullRequest, error) {
+			return nil, errors.New("boom a")
+		}},
+		"owner/b": &stubGitHubClient{searchFunc: func(ctx context.Context, query string) ([]PullRequest, error) {
+			return nil, errors.New("boom b")
+		}},
+	}
+
+	config := &Config{}
+	results, err := fetchAllRepositoryPRsWithSearch(context.Background(), []string{"owner/a", "owner/b"}, "", stubFactory(clients), config)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if results != nil {
+		t.Errorf("expected nil results on full failure, got %v", results)
+	}
+	if !strings.Contains(err.Error(), "boom a") || !strings.Contains(err.Error(), "boom b") {
+		t.Errorf("expected joined error to mention both failures, got: %v", err)
+	}
+}
+
+func TestFetchAllRepositoryPRsWithSearch_PartialFail(t *testing.T) {
+	clients := map[string]GitHubClient{
+		"owner/a": &stubGitHubClient{searchFunc: func(ctx context.Context, query string) ([]PullRequest, error) {
+			return []PullRequest{{Number: 1}}, nil
+		}},
+		"owner/b": &stubGitHubClient{searchFunc: func(ctx context.Context, query string) ([]PullRequest, error) {
+			return nil, errors.New("boom b")
+		}},
+	}
+
+	config := &Config{PartialResults: true}
+	results, err := fetchAllRepositoryPRsWithSearch(context.Background(), []string{"owner/a", "owner/b"}, "", stubFactory(clients), config)
+	if err == nil {
+		t.Fatal("expected non-nil error alongside partial results")
+	}
+	if len(results) != 1 || results[0].Repository != "owner/a" {
+		t.Fatalf("expected partial results for owner/a, got %v", results)
+	}
+}
+
+func TestFetchAllRepositoryPRsWithSearch_RetryThenSucceed(t *testing.T) {
+	attempts := 0
+	clients := map[string]GitHubClient{
+		"owner/a": &stubGitHubClient{searchFunc: func(ctx context.Context, query string) ([]PullRequest, error) {
+			attempts++
+			if attempts < 3 {
+				return nil, errors.New("transient")
+			}
+			return []PullRequest{{Number: 1}}, nil
+		}},
+	}
+
+	config := &Config{}
+	results, err := fetchAllRepositoryPRsWithSearch(context.Background(), []string{"owner/a"}, "", stubFactory(clients), config)
+	if err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 repository result, got %d", len(results))
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestFetchAllRepositoryPRsWithSearch_PreservesRepositoryOrder(t *testing.T) {
+	repos := []string{"owner/c", "owner/a", "owner/b"}
+	clients := make(map[string]GitHubClient)
+	for i, repo := range repos {
+		num := i + 1
+		// Later repositories finish first, so order can only be
+		// correct if it's derived from input position, not completion
+		// order.
+		delay := time.Duration(len(repos)-i) * time.Millisecond
+		clients[repo] = &stubGitHubClient{searchFunc: func(ctx context.Context, query string) ([]PullRequest, error) {
+			time.Sleep(delay)
+			return []PullRequest{{Number: num}}, nil
+		}}
+	}
+
+	config := &Config{MaxConcurrency: len(repos)}
+	results, err := fetchAllRepositoryPRsWithSearch(context.Background(), repos, "", stubFactory(clients), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != len(repos) {
+		t.Fatalf("expected %d results, got %d", len(repos), len(results))
+	}
+	for i, repo := range repos {
+		if results[i].Repository != repo {
+			t.Errorf("result[%d] = %q, want %q (order should follow input, not completion)", i, results[i].Repository, repo)
+		}
+	}
+}
+
+func TestFetchAllRepositoryPRsWithSearch_LimitsConcurrency(t *testing.T) {
+	const limit = 2
+	repos := []string{"owner/a", "owner/b", "owner/c", "owner/d", "owner/e", "owner/f"}
+
+	var inFlight int32
+	var maxInFlight int32
+	var mu sync.Mutex
+
+	clients := make(map[string]GitHubClient)
+	for _, repo := range repos {
+		clients[repo] = &stubGitHubClient{searchFunc: func(ctx context.Context, query string) ([]PullRequest, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			mu.Lock()
+			if n > maxInFlight {
+				maxInFlight = n
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			atomic.AddInt32(&inFlight, -1)
+			return nil, nil
+		}}
+	}
+
+	config := &Config{MaxConcurrency: limit}
+	if _, err := fetchAllRepositoryPRsWithSearch(context.Background(), repos, "", stubFactory(clients), config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if maxInFlight > limit {
+		t.Errorf("max in-flight calls = %d, want <= %d", maxInFlight, limit)
+	}
+	if maxInFlight < limit {
+		t.Errorf("max in-flight calls = %d, want exactly %d to confirm the semaphore is actually used", maxInFlight, limit)
+	}
+}
+
+func TestFetchAllRepositoryPRsWithSearch_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	clients := map[string]GitHubClient{
+		"owner/a": &stubGitHubClient{searchFunc: func(ctx context.Context, query string) ([]PullRequest, error) {
+			return nil, ctx.Err()
+		}},
+	}
+
+	config := &Config{}
+	_, err := fetchAllRepositoryPRsWithSearch(ctx, []string{"owner/a"}, "", stubFactory(clients), config)
+	if err == nil {
+		t.Fatal("expected error from cancelled context")
+	}
+}
@@ -0,0 +1,501 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterExpr is a compiled `--filter EXPR` post-filter: a boolean
+// expression evaluated against each PullRequest after the GitHub
+// search and --label/--author/etc. flags have narrowed the candidate
+// set, giving power users a single composable expression in place of
+// a growing pile of boolean flags.
+//
+// The expression environment exposes:
+//
+//	Number, Title, Author, Labels, Age, LastCommentedBy, CommentCount
+//	CIStatus(), HasLabel(label), FailingChecks()
+//	duration(s) - parses a Go duration literal, e.g. duration("48h")
+//
+// combined with !, &&, ||, parens, and the comparison operators ==,
+// !=, >, >=, <, <=, e.g.:
+//
+//	HasLabel("lgtm") && !HasLabel("approved") && Age > duration("48h") && CIStatus() == "Passing"
+//
+// This package has no go.mod (and so can't vendor
+// github.com/expr-lang/expr as the original request asked); this is a
+// small purpose-built evaluator covering the same expression shape.
+type FilterExpr struct {
+	root filterNode
+}
+
+// Evaluate runs the compiled expression against pr, returning an error
+// if the expression doesn't evaluate to a boolean (e.g. `--filter
+// Title` with no comparison).
+func (f *FilterExpr) Evaluate(pr PullRequest) (bool, error) {
+	v, err := f.root.eval(pr)
+	if err != nil {
+		return false, err
+	}
+	if v.kind != filterKindBool {
+		return false, fmt.Errorf("filter expression does not evaluate to a boolean (got a %s)", v.kind)
+	}
+	return v.b, nil
+}
+
+// CompileFilterExpr parses src into a FilterExpr, resolving every
+// identifier and function call against the known environment so that
+// typos are reported at compile time rather than silently failing
+// every PR.
+func CompileFilterExpr(src string) (*FilterExpr, error) {
+	p := &filterParser{tokens: tokeniseFilterExpr(src), src: src}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in filter expression %q", p.tokens[p.pos], src)
+	}
+	return &FilterExpr{root: node}, nil
+}
+
+type filterKind string
+
+const (
+	filterKindBool        filterKind = "bool"
+	filterKindString      filterKind = "string"
+	filterKindNumber      filterKind = "number"
+	filterKindDuration    filterKind = "duration"
+	filterKindStringSlice filterKind = "string list"
+)
+
+// filterValue is a dynamically-typed value produced while evaluating
+// a FilterExpr.
+type filterValue struct {
+	kind filterKind
+	b    bool
+	s    string
+	n    float64
+	d    time.Duration
+	ss   []string
+}
+
+// filterNode is one node of a compiled FilterExpr.
+type filterNode interface {
+	eval(pr PullRequest) (filterValue, error)
+}
+
+type filterLiteral struct{ v filterValue }
+
+func (n filterLiteral) eval(PullRequest) (filterValue, error) { return n.v, nil }
+
+type filterNot struct{ inner filterNode }
+
+func (n filterNot) eval(pr PullRequest) (filterValue, error) {
+	v, err := n.inner.eval(pr)
+	if err != nil {
+		return filterValue{}, err
+	}
+	if v.kind != filterKindBool {
+		return filterValue{}, fmt.Errorf("cannot negate a %s", v.kind)
+	}
+	return filterValue{kind: filterKindBool, b: !v.b}, nil
+}
+
+type filterAnd struct{ left, right filterNode }
+
+func (n filterAnd) eval(pr PullRequest) (filterValue, error) {
+	l, err := n.left.eval(pr)
+	if err != nil {
+		return filterValue{}, err
+	}
+	if l.kind != filterKindBool {
+		return filterValue{}, fmt.Errorf("left side of && is a %s, not a boolean", l.kind)
+	}
+	if !l.b {
+		return filterValue{kind: filterKindBool, b: false}, nil
+	}
+	r, err := n.right.eval(pr)
+	if err != nil {
+		return filterValue{}, err
+	}
+	if r.kind != filterKindBool {
+		return filterValue{}, fmt.Errorf("right side of && is a %s, not a boolean", r.kind)
+	}
+	return r, nil
+}
+
+type filterOr struct{ left, right filterNode }
+
+func (n filterOr) eval(pr PullRequest) (filterValue, error) {
+	l, err := n.left.eval(pr)
+	if err != nil {
+		return filterValue{}, err
+	}
+	if l.kind != filterKindBool {
+		return filterValue{}, fmt.Errorf("left side of || is a %s, not a boolean", l.kind)
+	}
+	if l.b {
+		return filterValue{kind: filterKindBool, b: true}, nil
+	}
+	r, err := n.right.eval(pr)
+	if err != nil {
+		return filterValue{}, err
+	}
+	if r.kind != filterKindBool {
+		return filterValue{}, fmt.Errorf("right side of || is a %s, not a boolean", r.kind)
+	}
+	return r, nil
+}
+
+// filterCompare implements ==, !=, >, >=, <, <= between two nodes of
+// matching kind (number-vs-number or duration-vs-duration for
+// ordering; any matching kind for equality).
+type filterCompare struct {
+	op          string
+	left, right filterNode
+}
+
+func (n filterCompare) eval(pr PullRequest) (filterValue, error) {
+	l, err := n.left.eval(pr)
+	if err != nil {
+		return filterValue{}, err
+	}
+	r, err := n.right.eval(pr)
+	if err != nil {
+		return filterValue{}, err
+	}
+	if l.kind != r.kind {
+		return filterValue{}, fmt.Errorf("cannot compare a %s to a %s", l.kind, r.kind)
+	}
+
+	switch n.op {
+	case "==", "!=":
+		eq, err := filterValuesEqual(l, r)
+		if err != nil {
+			return filterValue{}, err
+		}
+		if n.op == "!=" {
+			eq = !eq
+		}
+		return filterValue{kind: filterKindBool, b: eq}, nil
+	case ">", ">=", "<", "<=":
+		var left, right float64
+		switch l.kind {
+		case filterKindNumber:
+			left, right = l.n, r.n
+		case filterKindDuration:
+			left, right = float64(l.d), float64(r.d)
+		default:
+			return filterValue{}, fmt.Errorf("%s is not ordered, cannot use %s", l.kind, n.op)
+		}
+		var result bool
+		switch n.op {
+		case ">":
+			result = left > right
+		case ">=":
+			result = left >= right
+		case "<":
+			result = left < right
+		case "<=":
+			result = left <= right
+		}
+		return filterValue{kind: filterKindBool, b: result}, nil
+	default:
+		return filterValue{}, fmt.Errorf("unknown comparison operator %q", n.op)
+	}
+}
+
+func filterValuesEqual(l, r filterValue) (bool, error) {
+	switch l.kind {
+	case filterKindBool:
+		return l.b == r.b, nil
+	case filterKindString:
+		return l.s == r.s, nil
+	case filterKindNumber:
+		return l.n == r.n, nil
+	case filterKindDuration:
+		return l.d == r.d, nil
+	default:
+		return false, fmt.Errorf("%s cannot be compared for equality", l.kind)
+	}
+}
+
+// filterField reads one no-argument environment field or method, e.g.
+// Number, Title, or CIStatus().
+type filterField struct{ name string }
+
+func (n filterField) eval(pr PullRequest) (filterValue, error) {
+	switch n.name {
+	case "Number":
+		return filterValue{kind: filterKindNumber, n: float64(pr.Number)}, nil
+	case "Title":
+		return filterValue{kind: filterKindString, s: pr.Title}, nil
+	case "Author":
+		return filterValue{kind: filterKindString, s: pr.Author()}, nil
+	case "Labels":
+		return filterValue{kind: filterKindStringSlice, ss: pr.Labels}, nil
+	case "Age":
+		return filterValue{kind: filterKindDuration, d: pr.Age()}, nil
+	case "LastCommentedBy":
+		return filterValue{kind: filterKindString, s: pr.LastCommentedBy()}, nil
+	case "CommentCount":
+		return filterValue{kind: filterKindNumber, n: float64(pr.CommentCount())}, nil
+	case "CIStatus":
+		return filterValue{kind: filterKindString, s: pr.CIStatus()}, nil
+	case "FailingChecks":
+		return filterValue{kind: filterKindStringSlice, ss: pr.FailingChecks()}, nil
+	default:
+		return filterValue{}, fmt.Errorf("unknown identifier %q", n.name)
+	}
+}
+
+// filterHasLabel implements HasLabel("lgtm").
+type filterHasLabel struct{ label filterNode }
+
+func (n filterHasLabel) eval(pr PullRequest) (filterValue, error) {
+	arg, err := n.label.eval(pr)
+	if err != nil {
+		return filterValue{}, err
+	}
+	if arg.kind != filterKindString {
+		return filterValue{}, fmt.Errorf("HasLabel expects a string argument, got a %s", arg.kind)
+	}
+	for _, label := range pr.Labels {
+		if label == arg.s {
+			return filterValue{kind: filterKindBool, b: true}, nil
+		}
+	}
+	return filterValue{kind: filterKindBool, b: false}, nil
+}
+
+// filterDurationCall implements duration("48h").
+type filterDurationCall struct{ arg filterNode }
+
+func (n filterDurationCall) eval(pr PullRequest) (filterValue, error) {
+	arg, err := n.arg.eval(pr)
+	if err != nil {
+		return filterValue{}, err
+	}
+	if arg.kind != filterKindString {
+		return filterValue{}, fmt.Errorf("duration() expects a string argument, got a %s", arg.kind)
+	}
+	d, err := time.ParseDuration(arg.s)
+	if err != nil {
+		return filterValue{}, fmt.Errorf("invalid duration %q: %w", arg.s, err)
+	}
+	return filterValue{kind: filterKindDuration, d: d}, nil
+}
+
+// knownFilterFields are the zero-argument identifiers/methods
+// filterField recognises, used to give a compile-time error for typos
+// instead of waiting until eval time.
+var knownFilterFields = map[string]bool{
+	"Number": true, "Title": true, "Author": true, "Labels": true,
+	"Age": true, "LastCommentedBy": true, "CommentCount": true,
+	"CIStatus": true, "FailingChecks": true,
+}
+
+type filterParser struct {
+	tokens []string
+	pos    int
+	src    string
+}
+
+func (p *filterParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = filterAnd{left, right}
+	}
+	return left, nil
+}
+
+var filterComparisonOps = map[string]bool{"==": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if op := p.peek(); filterComparisonOps[op] {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterCompare{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.peek() == "!" {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("unexpected end of filter expression %q", p.src)
+	case tok == "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing closing paren in filter expression %q", p.src)
+		}
+		return expr, nil
+	case tok[0] == '"':
+		s, err := unquote(tok)
+		if err != nil {
+			return nil, err
+		}
+		return filterLiteral{filterValue{kind: filterKindString, s: s}}, nil
+	case isFilterNumber(tok):
+		n, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q in filter expression %q", tok, p.src)
+		}
+		return filterLiteral{filterValue{kind: filterKindNumber, n: n}}, nil
+	case tok == "HasLabel":
+		return p.parseSingleArgCall(tok, func(arg filterNode) filterNode { return filterHasLabel{label: arg} })
+	case tok == "duration":
+		return p.parseSingleArgCall(tok, func(arg filterNode) filterNode { return filterDurationCall{arg: arg} })
+	case p.peek() == "(" && (tok == "CIStatus" || tok == "FailingChecks"):
+		p.next()
+		if p.next() != ")" {
+			return nil, fmt.Errorf("%s() takes no arguments in filter expression %q", tok, p.src)
+		}
+		return filterField{name: tok}, nil
+	case knownFilterFields[tok]:
+		return filterField{name: tok}, nil
+	default:
+		return nil, fmt.Errorf("unknown identifier %q in filter expression %q", tok, p.src)
+	}
+}
+
+func (p *filterParser) parseSingleArgCall(name string, build func(arg filterNode) filterNode) (filterNode, error) {
+	if p.next() != "(" {
+		return nil, fmt.Errorf("expected ( after %s in filter expression %q", name, p.src)
+	}
+	arg, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.next() != ")" {
+		return nil, fmt.Errorf("expected ) after %s(...) in filter expression %q", name, p.src)
+	}
+	return build(arg), nil
+}
+
+func isFilterNumber(tok string) bool {
+	if tok == "" {
+		return false
+	}
+	_, err := strconv.ParseFloat(tok, 64)
+	return err == nil
+}
+
+// tokeniseFilterExpr splits a --filter expression into tokens:
+// identifiers, quoted strings, numbers, and the operators &&, ||, !,
+// ==, !=, >, >=, <, <=, (, ), ,.
+func tokeniseFilterExpr(src string) []string {
+	var tokens []string
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		rest := string(runes[i:])
+		switch {
+		case r == ' ' || r == '\t':
+			continue
+		case r == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j+1]))
+			i = j
+		case strings.HasPrefix(rest, "&&"):
+			tokens = append(tokens, "&&")
+			i++
+		case strings.HasPrefix(rest, "||"):
+			tokens = append(tokens, "||")
+			i++
+		case strings.HasPrefix(rest, "=="):
+			tokens = append(tokens, "==")
+			i++
+		case strings.HasPrefix(rest, "!="):
+			tokens = append(tokens, "!=")
+			i++
+		case strings.HasPrefix(rest, ">="):
+			tokens = append(tokens, ">=")
+			i++
+		case strings.HasPrefix(rest, "<="):
+			tokens = append(tokens, "<=")
+			i++
+		case r == '!' || r == '(' || r == ')' || r == ',' || r == '>' || r == '<':
+			tokens = append(tokens, string(r))
+		default:
+			j := i
+			for j < len(runes) && runes[j] != ' ' && runes[j] != '(' && runes[j] != ')' && runes[j] != ',' {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j - 1
+		}
+	}
+
+	return tokens
+}
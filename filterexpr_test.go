@@ -0,0 +1,109 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompileFilterExpr(t *testing.T) {
+	oldCreatedAt := time.Now().Add(-72 * time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		expr    string
+		pr      PullRequest
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "HasLabel match",
+			expr: `HasLabel("lgtm")`,
+			pr:   PullRequest{Labels: []string{"lgtm"}},
+			want: true,
+		},
+		{
+			name: "HasLabel no match",
+			expr: `HasLabel("lgtm")`,
+			pr:   PullRequest{Labels: []string{"approved"}},
+			want: false,
+		},
+		{
+			name: "and/or/not composition",
+			expr: `HasLabel("lgtm") && !HasLabel("approved")`,
+			pr:   PullRequest{Labels: []string{"lgtm"}},
+			want: true,
+		},
+		{
+			name: "string equality on Author",
+			expr: `Author == "octocat"`,
+			pr:   PullRequest{AuthorLogin: "octocat"},
+			want: true,
+		},
+		{
+			name: "number comparison on Number",
+			expr: `Number > 10`,
+			pr:   PullRequest{Number: 42},
+			want: true,
+		},
+		{
+			name: "duration comparison on Age",
+			expr: `Age > duration("48h")`,
+			pr:   PullRequest{CreatedAt: oldCreatedAt},
+			want: true,
+		},
+		{
+			name: "CIStatus call",
+			expr: `CIStatus() == "Passing"`,
+			pr:   PullRequest{},
+			want: true,
+		},
+		{
+			name:    "unknown identifier",
+			expr:    `Bogus == "x"`,
+			wantErr: true,
+		},
+		{
+			name:    "mismatched comparison kinds",
+			expr:    `Number == "10"`,
+			wantErr: true,
+		},
+		{
+			name:    "non-boolean expression",
+			expr:    `Title`,
+			pr:      PullRequest{Title: "hello"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled, err := CompileFilterExpr(tt.expr)
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatalf("CompileFilterExpr(%q) failed: %v", tt.expr, err)
+				}
+				return
+			}
+
+			got, err := compiled.Evaluate(tt.pr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Evaluate(%q) expected an error, got none", tt.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate(%q) failed: %v", tt.expr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileFilterExpr_InvalidSyntax(t *testing.T) {
+	if _, err := CompileFilterExpr(`HasLabel("lgtm"`); err == nil {
+		t.Fatal("expected an error for an unclosed paren")
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"runtime"
 	"slices"
 	"sort"
 	"strings"
@@ -15,7 +16,7 @@ import (
 type FlagInfo struct {
 	Name        string
 	ShortName   string
-	Type        string // "bool", "string", "stringSlice", "duration"
+	Type        string // "bool", "string", "stringSlice", "duration", "int"
 	Description string
 	Default     interface{}
 }
@@ -37,11 +38,22 @@ func (flag FlagInfo) Display() string {
 		display += " strings"
 	case "duration":
 		display += " duration"
+	case "int":
+		display += " int"
 	}
 
 	return display
 }
 
+// defaultConcurrency returns min(8, NumCPU), the default used by
+// --concurrency when the user doesn't override it.
+func defaultConcurrency() int {
+	if n := runtime.NumCPU(); n < 8 {
+		return n
+	}
+	return 8
+}
+
 // FlagCategory represents a group of related flags.
 type FlagCategory struct {
 	Name  string
@@ -57,12 +69,30 @@ func DefineAllFlags(availableActions map[string]ActionDefinition, availableTempl
 				{Name: "repo", ShortName: "r", Type: "string", Description: "GitHub repo (owner/repo)", Default: ""},
 			},
 		},
+		{
+			Name: "Profile:",
+			Flags: []FlagInfo{
+				{Name: "profile", ShortName: "", Type: "string", Description: "Apply a named profile from ~/.config/autoprat/config.yaml as defaults (CLI flags still win)", Default: ""},
+				{Name: "list-profiles", ShortName: "", Type: "bool", Description: "List available profiles and exit", Default: false},
+			},
+		},
 		{
 			Name: "Output:",
 			Flags: []FlagInfo{
 				{Name: "detailed", ShortName: "d", Type: "bool", Description: "Show detailed PR information", Default: false},
 				{Name: "detailed-with-logs", ShortName: "D", Type: "bool", Description: "Show detailed PR information with error logs from failing checks", Default: false},
 				{Name: "quiet", ShortName: "q", Type: "bool", Description: "Print PR numbers only", Default: false},
+				{Name: "output", ShortName: "", Type: "string", Description: "Output mode: json, ndjson, table, verbose, or quiet (overrides --detailed/--quiet)", Default: ""},
+				{Name: "template", ShortName: "", Type: "string", Description: "Use a named override template from ~/.config/autoprat/templates/*.tmpl for --detailed output (see --dump-template)", Default: ""},
+				{Name: "dump-template", ShortName: "", Type: "bool", Description: "Print the embedded default verbose template to stdout and exit", Default: false},
+			},
+		},
+		{
+			Name: "Watch:",
+			Flags: []FlagInfo{
+				{Name: "watch", ShortName: "", Type: "duration", Description: "Poll and reconcile on this interval instead of running once, posting only new or cooldown-elapsed matches", Default: time.Duration(0)},
+				{Name: "execute", ShortName: "", Type: "bool", Description: "In --watch mode, run generated gh commands directly (no shell) instead of only printing them", Default: false},
+				{Name: "state-file", ShortName: "", Type: "string", Description: "Path to the --watch state file (default: $XDG_STATE_HOME/autoprat/state.json)", Default: ""},
 			},
 		},
 		{
@@ -70,12 +100,26 @@ func DefineAllFlags(availableActions map[string]ActionDefinition, availableTempl
 			Flags: []FlagInfo{
 				{Name: "debug", ShortName: "", Type: "bool", Description: "Enable debug logging", Default: false},
 				{Name: "version", ShortName: "v", Type: "bool", Description: "Show version information", Default: false},
+				{Name: "version-format", ShortName: "", Type: "string", Description: "Format for --version: text (default), json, or yaml", Default: ""},
+				{Name: "print-context", ShortName: "", Type: "bool", Description: "Print the comment template context for matched PRs instead of posting, for debugging YAML templates", Default: false},
+				{Name: "backend", ShortName: "", Type: "string", Description: "GitHub client backend: gh (default) or api", Default: "gh"},
+				{Name: "concurrency", ShortName: "", Type: "int", Description: "Maximum number of repositories fetched concurrently", Default: defaultConcurrency()},
+				{Name: "continue-on-error", ShortName: "", Type: "bool", Description: "Continue with partial results if some repositories fail to fetch, instead of aborting", Default: false},
+				{Name: "refresh-templates", ShortName: "", Type: "bool", Description: "Re-fetch configured remote template registries instead of using their cache", Default: false},
+				{Name: "offline-templates", ShortName: "", Type: "bool", Description: "Skip remote template registries and use only what's already cached", Default: false},
 			},
 		},
 	}
 
 	// Add filters from available templates
 	var filterFlags []FlagInfo
+	filterFlags = append(filterFlags, FlagInfo{
+		Name:        "filter",
+		ShortName:   "",
+		Type:        "string",
+		Description: `Post-filter expression, e.g. HasLabel("lgtm") && !HasLabel("approved") && Age > duration("48h")`,
+		Default:     "",
+	})
 	for flag, template := range availableTemplates {
 		flagType := "bool"
 		var defaultVal interface{} = false
@@ -172,6 +216,8 @@ func registerFlags(categories []FlagCategory) map[string]interface{} {
 				}
 			case "duration":
 				flagRefs[flag.Name] = pflag.Duration(flag.Name, flag.Default.(time.Duration), flag.Description)
+			case "int":
+				flagRefs[flag.Name] = pflag.Int(flag.Name, flag.Default.(int), flag.Description)
 			}
 		}
 	}
@@ -275,6 +321,86 @@ func parseAndValidateArgs(availableActions map[string]ActionDefinition, actionFl
 	detailed := flagRefs["detailed"].(*bool)
 	detailedWithLogs := flagRefs["detailed-with-logs"].(*bool)
 	quiet := flagRefs["quiet"].(*bool)
+	printContext := flagRefs["print-context"].(*bool)
+	output := flagRefs["output"].(*string)
+	templateName := flagRefs["template"].(*string)
+	filterExprSrc := flagRefs["filter"].(*string)
+	profileName := flagRefs["profile"].(*string)
+	backend := flagRefs["backend"].(*string)
+	concurrency := flagRefs["concurrency"].(*int)
+	continueOnError := flagRefs["continue-on-error"].(*bool)
+	watch := flagRefs["watch"].(*time.Duration)
+	execute := flagRefs["execute"].(*bool)
+	stateFile := flagRefs["state-file"].(*string)
+
+	if *watch < 0 {
+		return nil, fmt.Errorf("invalid --watch %s, must be zero or positive", *watch)
+	}
+
+	if *execute && *watch == 0 {
+		return nil, fmt.Errorf("--execute requires --watch")
+	}
+
+	if *backend != "gh" && *backend != "api" {
+		return nil, fmt.Errorf("invalid --backend %q, must be one of: gh, api", *backend)
+	}
+
+	if *concurrency < 0 {
+		return nil, fmt.Errorf("invalid --concurrency %d, must be zero or positive", *concurrency)
+	}
+
+	var resolvedProfile Profile
+	if *profileName != "" {
+		profiles, ok, err := loadProfiles()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("--profile %q given, but no profiles file found at %s", *profileName, defaultProfilesConfigFile())
+		}
+		resolvedProfile, err = resolveProfile(profiles, *profileName)
+		if err != nil {
+			return nil, fmt.Errorf("--profile %q: %w", *profileName, err)
+		}
+
+		// A profile's own scalar settings only apply where the CLI
+		// didn't already provide an explicit value, same as
+		// projectDefaults below.
+		if !*detailed {
+			*detailed = resolvedProfile.Detailed
+		}
+		if !*quiet {
+			*quiet = resolvedProfile.Quiet
+		}
+		if *output == "" {
+			*output = resolvedProfile.Output
+		}
+		if *filterExprSrc == "" {
+			*filterExprSrc = resolvedProfile.Filter
+		}
+	}
+
+	switch *output {
+	case "", "json", "ndjson", "table", "verbose", "quiet":
+	default:
+		return nil, fmt.Errorf("invalid --output %q, must be one of: json, ndjson, table, verbose, quiet", *output)
+	}
+
+	if *templateName != "" {
+		if _, err := resolveVerboseTemplate(*templateName); err != nil {
+			return nil, err
+		}
+	}
+
+	var compiledFilter *FilterExpr
+	if *filterExprSrc != "" {
+		compiled, err := CompileFilterExpr(*filterExprSrc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --filter: %w", err)
+		}
+		compiledFilter = compiled
+	}
+
 	prNumbers := pflag.Args()
 
 	var parsedPRs []PullRequestRef
@@ -299,6 +425,25 @@ func parseAndValidateArgs(availableActions map[string]ActionDefinition, actionFl
 		repositories[*repo] = true
 	}
 
+	projectDefaults, hasProjectDefaults, err := loadProjectDefaults()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// Fall back to the profile's, then the project's, default
+	// repositories only when nothing on the command line named any, so
+	// CLI args always win.
+	if len(repositories) == 0 && !hasNumericArgs && len(prNumbers) == 0 {
+		for _, r := range resolvedProfile.Repositories {
+			repositories[r] = true
+		}
+	}
+	if len(repositories) == 0 && !hasNumericArgs && len(prNumbers) == 0 {
+		for _, r := range projectDefaults.Repositories {
+			repositories[r] = true
+		}
+	}
+
 	if len(repositories) == 0 && (hasNumericArgs || len(prNumbers) == 0) {
 		return nil, fmt.Errorf("--repo is required when using numeric PR arguments or no PR arguments")
 	}
@@ -310,6 +455,7 @@ func parseAndValidateArgs(availableActions map[string]ActionDefinition, actionFl
 	sort.Strings(repoList)
 
 	var allActions []Action
+	var workflowActions []ActionDefinition
 	for _, c := range *comment {
 		allActions = append(allActions, Action{
 			Comment:   c,
@@ -320,10 +466,31 @@ func parseAndValidateArgs(availableActions map[string]ActionDefinition, actionFl
 	for flag, flagPtr := range actionFlags {
 		if *flagPtr {
 			actionDef, exists := availableActions[flag]
-			if exists {
-				allActions = append(allActions, actionDef.ToAction())
+			if !exists {
+				continue
 			}
+			if len(actionDef.Steps) > 0 {
+				workflowActions = append(workflowActions, actionDef)
+				continue
+			}
+			allActions = append(allActions, actionDef.ToAction())
+		}
+	}
+
+	// Apply any profile actions not already toggled on the command line.
+	for _, flag := range resolvedProfile.Actions {
+		if flagPtr, ok := actionFlags[flag]; ok && *flagPtr {
+			continue
 		}
+		actionDef, exists := availableActions[flag]
+		if !exists {
+			continue
+		}
+		if len(actionDef.Steps) > 0 {
+			workflowActions = append(workflowActions, actionDef)
+			continue
+		}
+		allActions = append(allActions, actionDef.ToAction())
 	}
 
 	// Build search query from templates
@@ -360,17 +527,55 @@ func parseAndValidateArgs(availableActions map[string]ActionDefinition, actionFl
 		}
 	}
 
+	// Apply any profile filters not already toggled on the command line.
+	for _, flag := range resolvedProfile.Filters {
+		if flagPtr, ok := templateFlags[flag]; ok && *flagPtr {
+			continue
+		}
+		if template, exists := availableTemplates[flag]; exists && !template.Parameterized {
+			queryTerms = append(queryTerms, template.Query)
+		}
+	}
+
+	// Apply any project-default filters not already toggled on the
+	// command line, and fall back to the project's default search
+	// query when nothing on the command line contributed any terms.
+	if hasProjectDefaults {
+		for _, flag := range projectDefaults.Filters {
+			if flagPtr, ok := templateFlags[flag]; ok && *flagPtr {
+				continue
+			}
+			if template, exists := availableTemplates[flag]; exists && !template.Parameterized {
+				queryTerms = append(queryTerms, template.Query)
+			}
+		}
+		if len(queryTerms) == 0 && projectDefaults.SearchQuery != "" {
+			queryTerms = append(queryTerms, projectDefaults.SearchQuery)
+		}
+	}
+
 	searchQuery := strings.Join(queryTerms, " ")
 
 	return &Config{
 		Repositories:     repoList,
 		ParsedPRs:        parsedPRs,
 		Actions:          allActions,
+		WorkflowActions:  workflowActions,
 		SearchQuery:      searchQuery,
 		Throttle:         *throttle,
 		DebugMode:        *debugMode,
 		Detailed:         *detailed,
 		DetailedWithLogs: *detailedWithLogs,
 		Quiet:            *quiet,
+		PrintContext:     *printContext,
+		Output:           *output,
+		Template:         *templateName,
+		Filter:           compiledFilter,
+		Backend:          *backend,
+		MaxConcurrency:   *concurrency,
+		PartialResults:   *continueOnError,
+		WatchInterval:    *watch,
+		Execute:          *execute,
+		StateFile:        *stateFile,
 	}, nil
 }
@@ -175,7 +175,7 @@ func (f *VerboseFormatter) Format(result Result, config *Config) error {
 			fmt.Printf("Repository: %s\n", repoPRs.Repository)
 			fmt.Println(strings.Repeat("=", len(repoPRs.Repository)+12))
 			for _, pr := range repoPRs.PRs {
-				if err := printDetailedPR(pr, config.DetailedWithLogs); err != nil {
+				if err := printDetailedPR(pr, config.DetailedWithLogs, config.Template); err != nil {
 					return fmt.Errorf("failed to print detailed PR: %w", err)
 				}
 				if config.Throttle > 0 {
@@ -204,9 +204,16 @@ func (f *QuietFormatter) Format(result Result, config *Config) error {
 	return nil
 }
 
-// printDetailedPR renders a PR using the verbose template.
-func printDetailedPR(prItem PullRequest, showLogs bool) error {
-	tmpl, err := template.New("verbose").Funcs(templateFuncs).Parse(verboseTemplate)
+// printDetailedPR renders a PR using the verbose template, or the
+// named override template if templateName is non-empty (see
+// resolveVerboseTemplate).
+func printDetailedPR(prItem PullRequest, showLogs bool, templateName string) error {
+	templateBody, err := resolveVerboseTemplate(templateName)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("verbose").Funcs(templateFuncs).Parse(templateBody)
 	if err != nil {
 		return fmt.Errorf("template parse error: %w", err)
 	}
@@ -0,0 +1,68 @@
+// Package apiclient implements a GitHub PR search client against the
+// REST/GraphQL API directly via go-github, as an alternative to
+// shelling out to the gh CLI. It exists so library consumers and CI
+// containers that don't want a gh binary dependency can still use
+// autoprat's search and filtering.
+package apiclient
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/google/go-github/v58/github"
+	"golang.org/x/oauth2"
+)
+
+// Client runs PR searches against the GitHub API using a token
+// resolved from the environment, instead of delegating to gh.
+type Client struct {
+	gh   *github.Client
+	repo string
+}
+
+// NewClient constructs a Client authenticated against repo
+// (owner/name), using a token from GITHUB_TOKEN or GH_TOKEN.
+func NewClient(repo string) (*Client, error) {
+	token, err := resolveToken()
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := oauth2.NewClient(oauth2.NoContext, oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	))
+
+	return &Client{
+		gh:   github.NewClient(httpClient),
+		repo: repo,
+	}, nil
+}
+
+// newClientWithHTTP constructs a Client around an injected http.Client,
+// so tests can point it at a local httptest.Server instead of the
+// real GitHub API.
+func newClientWithHTTP(repo, baseURL string, httpClient *http.Client) (*Client, error) {
+	gh := github.NewClient(httpClient)
+	if baseURL != "" {
+		u, err := gh.BaseURL.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+		}
+		gh.BaseURL = u
+	}
+	return &Client{gh: gh, repo: repo}, nil
+}
+
+// resolveToken returns the first of GITHUB_TOKEN or GH_TOKEN that is
+// set, matching gh's own precedence, so existing CI secrets work
+// unchanged when switching --backend=api.
+func resolveToken() (string, error) {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token, nil
+	}
+	if token := os.Getenv("GH_TOKEN"); token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("apiclient: no token found in GITHUB_TOKEN or GH_TOKEN")
+}
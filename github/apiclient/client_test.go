@@ -0,0 +1,64 @@
+package apiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveToken_PrefersGitHubToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	t.Setenv("GH_TOKEN", "gh-cli-token")
+
+	token, err := resolveToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "gh-token" {
+		t.Errorf("expected GITHUB_TOKEN to win, got %q", token)
+	}
+}
+
+func TestResolveToken_FallsBackToGHToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "gh-cli-token")
+
+	token, err := resolveToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "gh-cli-token" {
+		t.Errorf("expected GH_TOKEN fallback, got %q", token)
+	}
+}
+
+func TestResolveToken_MissingBoth(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+
+	if _, err := resolveToken(); err == nil {
+		t.Fatal("expected an error when no token is set")
+	}
+}
+
+func TestMergeableString(t *testing.T) {
+	yes, no := true, false
+	if got := mergeableString(nil); got != "UNKNOWN" {
+		t.Errorf("mergeableString(nil) = %q, want UNKNOWN", got)
+	}
+	if got := mergeableString(&yes); got != "MERGEABLE" {
+		t.Errorf("mergeableString(true) = %q, want MERGEABLE", got)
+	}
+	if got := mergeableString(&no); got != "CONFLICTING" {
+		t.Errorf("mergeableString(false) = %q, want CONFLICTING", got)
+	}
+}
+
+func TestNewClientWithHTTP_InvalidBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := newClientWithHTTP("owner/repo", "://not-a-url", server.Client()); err == nil {
+		t.Fatal("expected an error for an invalid base URL")
+	}
+}
@@ -0,0 +1,102 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gogithub "github.com/google/go-github/v58/github"
+
+	"github.com/frobware/autoprat/github"
+)
+
+// enrichPullRequest converts a search hit into a github.PullRequest,
+// backfilling the fields the Search Issues endpoint doesn't return
+// (head branch, mergeability, check rollup, changed files) with
+// additional calls, so filters written against the gh-backed client
+// work unchanged against this backend.
+func (c *Client) enrichPullRequest(ctx context.Context, issue *gogithub.Issue) (github.PullRequest, error) {
+	owner, name, found := strings.Cut(c.repo, "/")
+	if !found {
+		return github.PullRequest{}, fmt.Errorf("invalid repository format: %s", c.repo)
+	}
+
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, label.GetName())
+	}
+
+	pr := github.PullRequest{
+		Number:      issue.GetNumber(),
+		Title:       issue.GetTitle(),
+		Body:        issue.GetBody(),
+		CreatedAt:   issue.GetCreatedAt().Format("2006-01-02T15:04:05Z"),
+		Labels:      labels,
+		AuthorLogin: issue.GetUser().GetLogin(),
+		URL:         issue.GetHTMLURL(),
+		State:       strings.ToUpper(issue.GetState()),
+	}
+
+	var detail *gogithub.PullRequest
+	err := c.withBackoff(ctx, func() error {
+		var detailErr error
+		var resp *gogithub.Response
+		detail, resp, detailErr = c.gh.PullRequests.Get(ctx, owner, name, pr.Number)
+		return rateLimitAware(detailErr, resp)
+	})
+	if err != nil {
+		return github.PullRequest{}, fmt.Errorf("fetch PR detail: %w", err)
+	}
+
+	pr.HeadRefName = detail.GetHead().GetRef()
+	pr.Mergeable = mergeableString(detail.Mergeable)
+	pr.MergeStateStatus = strings.ToUpper(detail.GetMergeableState())
+
+	var files []*gogithub.CommitFile
+	err = c.withBackoff(ctx, func() error {
+		var filesErr error
+		var resp *gogithub.Response
+		files, resp, filesErr = c.gh.PullRequests.ListFiles(ctx, owner, name, pr.Number, nil)
+		return rateLimitAware(filesErr, resp)
+	})
+	if err != nil {
+		return github.PullRequest{}, fmt.Errorf("list changed files: %w", err)
+	}
+	for _, f := range files {
+		pr.ChangedFiles = append(pr.ChangedFiles, f.GetFilename())
+	}
+
+	var checks *gogithub.ListCheckRunsResults
+	err = c.withBackoff(ctx, func() error {
+		var checksErr error
+		var resp *gogithub.Response
+		checks, resp, checksErr = c.gh.Checks.ListCheckRunsForRef(ctx, owner, name, detail.GetHead().GetSHA(), nil)
+		return rateLimitAware(checksErr, resp)
+	})
+	if err != nil {
+		return github.PullRequest{}, fmt.Errorf("list check runs: %w", err)
+	}
+	for _, run := range checks.CheckRuns {
+		pr.StatusCheckRollup.Contexts.Nodes = append(pr.StatusCheckRollup.Contexts.Nodes, github.StatusCheck{
+			Name:       run.GetName(),
+			Conclusion: strings.ToUpper(run.GetConclusion()),
+			DetailsUrl: run.GetDetailsURL(),
+		})
+	}
+
+	return pr, nil
+}
+
+// mergeableString stringifies go-github's *bool Mergeable the way
+// GraphQL's mergeable enum does, so github.PullRequest.MergeState sees
+// the same "MERGEABLE"/"CONFLICTING"/"UNKNOWN" values regardless of
+// backend.
+func mergeableString(mergeable *bool) string {
+	if mergeable == nil {
+		return "UNKNOWN"
+	}
+	if *mergeable {
+		return "MERGEABLE"
+	}
+	return "CONFLICTING"
+}
@@ -0,0 +1,139 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gogithub "github.com/google/go-github/v58/github"
+
+	"github.com/frobware/autoprat/github"
+)
+
+// defaultSearchRetries is how many attempts withBackoff makes at a
+// single API call (a search page or one of the enrichment calls),
+// counting the first, before giving up after secondary rate limits or
+// transient errors, in the style of the gh-backed client's
+// fetchRepositoryPRsWithRetry.
+const defaultSearchRetries = 5
+
+// Search runs query (the same GitHub search syntax buildQuery produces
+// for the gh-backed client) against the REST search API, paginating
+// through all results and enriching each hit with the PR detail,
+// check-run, and file data GraphQL would otherwise return in one
+// round-trip.
+func (c *Client) Search(ctx context.Context, query string) ([]github.PullRequest, error) {
+	var issues []*gogithub.Issue
+
+	opts := &gogithub.SearchOptions{ListOptions: gogithub.ListOptions{PerPage: 100}}
+	for {
+		var result *gogithub.IssuesSearchResult
+		var resp *gogithub.Response
+		err := c.withBackoff(ctx, func() error {
+			var searchErr error
+			result, resp, searchErr = c.gh.Search.Issues(ctx, query, opts)
+			return rateLimitAware(searchErr, resp)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("apiclient: search %q: %w", query, err)
+		}
+
+		issues = append(issues, result.Issues...)
+
+		if result.GetIncompleteResults() || len(issues) >= result.GetTotal() {
+			break
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	prs := make([]github.PullRequest, 0, len(issues))
+	for _, issue := range issues {
+		pr, err := c.enrichPullRequest(ctx, issue)
+		if err != nil {
+			return nil, fmt.Errorf("apiclient: enrich PR #%d: %w", issue.GetNumber(), err)
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, nil
+}
+
+// withBackoff retries fn with exponential backoff, honouring a
+// SecondaryRateLimitError's RetryAfter, matching the behaviour of
+// run.go's fetchRepositoryPRsWithRetry on the gh-backed path.
+func (c *Client) withBackoff(ctx context.Context, fn func() error) error {
+	backoff := 500 * time.Millisecond
+
+	var lastErr error
+	for attempt := 0; attempt < defaultSearchRetries; attempt++ {
+		if attempt > 0 {
+			wait := backoff
+			var rateLimitErr *SecondaryRateLimitError
+			if errors.As(lastErr, &rateLimitErr) && rateLimitErr.RetryAfter > 0 {
+				wait = rateLimitErr.RetryAfter
+			}
+
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff *= 2
+		}
+
+		if err := fn(); err == nil {
+			return nil
+		} else {
+			lastErr = err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	return lastErr
+}
+
+// rateLimitAware wraps err as a *SecondaryRateLimitError when resp
+// indicates GitHub's secondary (abuse) rate limit, so withBackoff can
+// honour the Retry-After it asked for.
+func rateLimitAware(err error, resp *gogithub.Response) error {
+	if err == nil {
+		return nil
+	}
+
+	var abuseErr *gogithub.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		retryAfter := time.Minute
+		if abuseErr.RetryAfter != nil {
+			retryAfter = *abuseErr.RetryAfter
+		}
+		return &SecondaryRateLimitError{RetryAfter: retryAfter, Err: err}
+	}
+
+	if resp != nil && resp.StatusCode == 403 && resp.Rate.Remaining == 0 {
+		return &SecondaryRateLimitError{RetryAfter: time.Until(resp.Rate.Reset.Time), Err: err}
+	}
+
+	return err
+}
+
+// SecondaryRateLimitError indicates GitHub asked the caller to back
+// off for a specific duration, per its secondary rate limit headers.
+type SecondaryRateLimitError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *SecondaryRateLimitError) Error() string {
+	return fmt.Sprintf("secondary rate limit, retry after %s: %v", e.RetryAfter, e.Err)
+}
+
+func (e *SecondaryRateLimitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,241 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxAliasesPerRequest bounds how many aliased `search` fields go into
+// a single GraphQL document. GitHub's GraphQL API rejects documents
+// with too many top-level fields, so batches larger than this are
+// chunked into multiple round-trips.
+const maxAliasesPerRequest = 20
+
+// RateLimit reports the GraphQL rate-limit state returned alongside
+// the most recent batched search.
+type RateLimit struct {
+	Cost      int
+	Remaining int
+	ResetAt   string
+}
+
+var (
+	lastRateLimitMu sync.Mutex
+	lastRateLimit   RateLimit
+)
+
+// LastRateLimit returns the rateLimit block from the most recent
+// batchSearch call, so long-running autoprat processes can back off
+// before they hit GitHub's secondary rate limits.
+func LastRateLimit() RateLimit {
+	lastRateLimitMu.Lock()
+	defer lastRateLimitMu.Unlock()
+	return lastRateLimit
+}
+
+// pullRequestSelectionSet is the GraphQL selection set for a
+// PullRequest, shared between queries/search-prs.graphql and the
+// dynamically-aliased batch document below.
+const pullRequestSelectionSet = `
+        number
+        title
+        body
+        headRefName
+        createdAt
+        state
+        url
+        mergeable
+        mergeStateStatus
+        isInMergeQueue
+        isMergeQueueEnabled
+        baseRef {
+          branchProtectionRule {
+            requiredStatusCheckContexts
+          }
+        }
+        author {
+          login
+        }
+        labels(first: 100) {
+          nodes {
+            name
+          }
+        }
+        statusCheckRollup {
+          contexts(first: 100) {
+            nodes {
+              ... on StatusContext {
+                context
+                state
+                targetUrl
+              }
+              ... on CheckRun {
+                name
+                conclusion
+                detailsUrl
+              }
+            }
+          }
+        }
+        comments(last: 50) {
+          nodes {
+            body
+            createdAt
+            author {
+              login
+            }
+          }
+        }
+        files(first: 100) {
+          nodes {
+            path
+          }
+        }`
+
+// batchSearch runs queries against the default go-gh client. It is a
+// thin wrapper over batchSearchWith for callers that don't need client
+// injection (e.g. dashboard.go's package-level helpers).
+func batchSearch(queries map[string]string) (map[string][]PullRequest, error) {
+	client, err := newDefaultGQLClient()
+	if err != nil {
+		return nil, err
+	}
+	return batchSearchWith(client, queries)
+}
+
+// batchSearchWith runs queries (keyed by caller-chosen bucket name) as
+// one or more aliased GraphQL documents — one alias per query, up to
+// maxAliasesPerRequest per round-trip — instead of one request per
+// query, against client.
+func batchSearchWith(client GQLClient, queries map[string]string) (map[string][]PullRequest, error) {
+	names := make([]string, 0, len(queries))
+	for name := range queries {
+		names = append(names, name)
+	}
+
+	results := make(map[string][]PullRequest, len(queries))
+
+	for start := 0; start < len(names); start += maxAliasesPerRequest {
+		end := start + maxAliasesPerRequest
+		if end > len(names) {
+			end = len(names)
+		}
+
+		chunkResults, err := doBatchSearch(client, names[start:end], queries)
+		if err != nil {
+			return nil, err
+		}
+		for name, prs := range chunkResults {
+			results[name] = prs
+		}
+	}
+
+	return results, nil
+}
+
+// alias returns the syntactically valid, collision-free GraphQL alias
+// for the query at index i of a batch document.
+func alias(i int) string {
+	return "q" + strconv.Itoa(i)
+}
+
+func doBatchSearch(client GQLClient, names []string, queries map[string]string) (map[string][]PullRequest, error) {
+	var doc strings.Builder
+	doc.WriteString("query BatchSearch(")
+	for i := range names {
+		if i > 0 {
+			doc.WriteString(", ")
+		}
+		fmt.Fprintf(&doc, "$%s: String!", alias(i))
+	}
+	doc.WriteString(") {\n")
+	for i := range names {
+		a := alias(i)
+		fmt.Fprintf(&doc, "  %s: search(query: $%s, type: ISSUE, first: 100) {\n    nodes {\n      ... on PullRequest {%s\n      }\n    }\n  }\n", a, a, pullRequestSelectionSet)
+	}
+	doc.WriteString("  rateLimit {\n    cost\n    remaining\n    resetAt\n  }\n}\n")
+
+	vars := make(map[string]any, len(names))
+	for i, name := range names {
+		vars[alias(i)] = queries[name]
+	}
+
+	var resp map[string]json.RawMessage
+	if err := client.Do(doc.String(), vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if raw, ok := resp["rateLimit"]; ok {
+		var rl struct {
+			Cost      int    `json:"cost"`
+			Remaining int    `json:"remaining"`
+			ResetAt   string `json:"resetAt"`
+		}
+		if err := json.Unmarshal(raw, &rl); err == nil {
+			lastRateLimitMu.Lock()
+			lastRateLimit = RateLimit(rl)
+			lastRateLimitMu.Unlock()
+		}
+	}
+
+	results := make(map[string][]PullRequest, len(names))
+	for i, name := range names {
+		raw, ok := resp[alias(i)]
+		if !ok {
+			continue
+		}
+
+		var searchResult struct {
+			Nodes []graphQLPullRequest `json:"nodes"`
+		}
+		if err := json.Unmarshal(raw, &searchResult); err != nil {
+			return nil, fmt.Errorf("failed to decode batched search result for %q: %w", name, err)
+		}
+
+		results[name] = toPullRequests(searchResult.Nodes)
+	}
+
+	return results, nil
+}
+
+// toPullRequests converts the GraphQL response shape into the package's
+// PullRequest type, shared by both searchPullRequests and batchSearch.
+func toPullRequests(nodes []graphQLPullRequest) []PullRequest {
+	prs := make([]PullRequest, 0, len(nodes))
+	for _, gqlPR := range nodes {
+		labelNames := make([]string, 0, len(gqlPR.Labels.Nodes))
+		for _, label := range gqlPR.Labels.Nodes {
+			labelNames = append(labelNames, label.Name)
+		}
+
+		changedFiles := make([]string, 0, len(gqlPR.Files.Nodes))
+		for _, file := range gqlPR.Files.Nodes {
+			changedFiles = append(changedFiles, file.Path)
+		}
+
+		prs = append(prs, PullRequest{
+			Number:               gqlPR.Number,
+			Title:                gqlPR.Title,
+			Body:                 gqlPR.Body,
+			HeadRefName:          gqlPR.HeadRefName,
+			CreatedAt:            gqlPR.CreatedAt,
+			State:                gqlPR.State,
+			Labels:               labelNames,
+			AuthorLogin:          gqlPR.Author.Login,
+			URL:                  gqlPR.URL,
+			Mergeable:            gqlPR.Mergeable,
+			MergeStateStatus:     gqlPR.MergeStateStatus,
+			IsInMergeQueue:       gqlPR.IsInMergeQueue,
+			IsMergeQueueEnabled:  gqlPR.IsMergeQueueEnabled,
+			RequiredStatusChecks: gqlPR.BaseRef.BranchProtectionRule.RequiredStatusCheckContexts,
+			StatusCheckRollup:    gqlPR.StatusCheckRollup,
+			Comments:             gqlPR.Comments.Nodes,
+			ChangedFiles:         changedFiles,
+			repo:                 extractRepoFromURL(gqlPR.URL),
+		})
+	}
+	return prs
+}
@@ -0,0 +1,42 @@
+package github
+
+import "testing"
+
+func TestAliasIsUniquePerIndex(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < maxAliasesPerRequest; i++ {
+		a := alias(i)
+		if seen[a] {
+			t.Fatalf("alias(%d) = %q collides with an earlier alias", i, a)
+		}
+		seen[a] = true
+	}
+}
+
+func TestBatchSearchChunksBeyondAliasCap(t *testing.T) {
+	queries := make(map[string]string, maxAliasesPerRequest+5)
+	for i := 0; i < maxAliasesPerRequest+5; i++ {
+		queries[alias(i)] = "is:pr is:open"
+	}
+
+	client := &countingBatchClient{}
+	if _, err := batchSearchWith(client, queries); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	chunks := client.calls
+
+	if chunks != 2 {
+		t.Errorf("expected 2 chunks for %d queries with cap %d, got %d", len(queries), maxAliasesPerRequest, chunks)
+	}
+}
+
+// countingBatchClient counts the GraphQL documents batchSearchWith
+// sends, returning an empty response for each.
+type countingBatchClient struct {
+	calls int
+}
+
+func (c *countingBatchClient) Do(query string, vars map[string]any, resp any) error {
+	c.calls++
+	return nil
+}
@@ -0,0 +1,42 @@
+package github
+
+import "context"
+
+// PullRequestsPayload groups PRs relevant to the authenticated viewer
+// into the buckets `autoprat status` prints: PRs the viewer opened,
+// PRs where their review was requested, PRs that mention them, and PRs
+// assigned to them.
+type PullRequestsPayload struct {
+	ViewerCreated   []PullRequest
+	ReviewRequested []PullRequest
+	Mentioned       []PullRequest
+	Assigned        []PullRequest
+}
+
+// dashboardQueries maps each PullRequestsPayload bucket to the search
+// qualifiers that populate it.
+var dashboardQueries = map[string]string{
+	"viewerCreated":   "is:pr is:open author:@me",
+	"reviewRequested": "is:pr is:open review-requested:@me",
+	"mentioned":       "is:pr is:open mentions:@me",
+	"assigned":        "is:pr is:open assignee:@me",
+}
+
+// DashboardForViewer fetches the four dashboard buckets for the
+// authenticated user as a single batched GraphQL request (see
+// batchSearch), instead of four separate round-trips. ctx is accepted
+// for future cancellation support; batchSearch does not yet thread it
+// through to the underlying gh.GQLClient call.
+func DashboardForViewer(ctx context.Context) (PullRequestsPayload, error) {
+	results, err := batchSearch(dashboardQueries)
+	if err != nil {
+		return PullRequestsPayload{}, err
+	}
+
+	return PullRequestsPayload{
+		ViewerCreated:   results["viewerCreated"],
+		ReviewRequested: results["reviewRequested"],
+		Mentioned:       results["mentioned"],
+		Assigned:        results["assigned"],
+	}, nil
+}
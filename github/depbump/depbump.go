@@ -0,0 +1,303 @@
+// Package depbump classifies dependency-update PRs (Dependabot,
+// Renovate, or a custom bot) by the semver bump their title describes,
+// so filters and actions can gate on "patch-only" auto-merge policies
+// instead of relying on labels alone.
+package depbump
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed embedded/*.yaml
+var embeddedRules embed.FS
+
+// Bump classifies the semver significance of a dependency update.
+type Bump int
+
+const (
+	BumpUnknown Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// String returns the lower-case name used in YAML/CLI ("patch",
+// "minor", "major"), matching FilterDefinition's bump_level field.
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseBump converts a YAML/CLI bump level name to a Bump.
+func ParseBump(s string) (Bump, bool) {
+	switch s {
+	case "patch":
+		return BumpPatch, true
+	case "minor":
+		return BumpMinor, true
+	case "major":
+		return BumpMajor, true
+	default:
+		return BumpUnknown, false
+	}
+}
+
+// Rule matches a dependency-update PR by title or branch name and
+// extracts the old/new version strings used to classify the bump.
+type Rule struct {
+	Name          string `yaml:"name"`
+	TitlePattern  string `yaml:"title_pattern,omitempty"`
+	BranchPattern string `yaml:"branch_pattern,omitempty"`
+
+	Source string `yaml:"-"`
+
+	titleRe  *regexp.Regexp
+	branchRe *regexp.Regexp
+}
+
+// versions extracts the "from"/"to" capture groups from whichever of
+// TitlePattern/BranchPattern matches, in that order.
+func (r Rule) versions(title, branch string) (from, to string, ok bool) {
+	if r.titleRe != nil {
+		if from, to, ok := extractVersions(r.titleRe, title); ok {
+			return from, to, true
+		}
+	}
+	if r.branchRe != nil {
+		if from, to, ok := extractVersions(r.branchRe, branch); ok {
+			return from, to, true
+		}
+	}
+	return "", "", false
+}
+
+func extractVersions(re *regexp.Regexp, s string) (from, to string, ok bool) {
+	match := re.FindStringSubmatch(s)
+	if match == nil {
+		return "", "", false
+	}
+	from = match[re.SubexpIndex("from")]
+	to = match[re.SubexpIndex("to")]
+	if from == "" || to == "" {
+		return "", "", false
+	}
+	return from, to, true
+}
+
+// Registry holds the rule table used to recognise dependency-update
+// PRs, loaded from embedded defaults plus the user's config directory.
+type Registry struct {
+	rules []Rule
+}
+
+// NewRegistry loads the embedded dependabot/renovate rules plus any
+// user-defined rules from ~/.config/autoprat/depbump/.
+func NewRegistry() (*Registry, error) {
+	r := &Registry{}
+
+	if err := r.loadEmbeddedRules(); err != nil {
+		return nil, fmt.Errorf("failed to load embedded depbump rules: %w", err)
+	}
+
+	if err := r.loadUserRules(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load user depbump rules: %v\n", err)
+	}
+
+	return r, nil
+}
+
+// NewRegistryFromDocuments builds a Registry directly from a sequence
+// of YAML rule documents, bypassing the embedded/user config lookup.
+// It's used where the caller already has rule definitions in hand
+// (tests, or callers composing rules from another source).
+func NewRegistryFromDocuments(docs ...string) (*Registry, error) {
+	r := &Registry{}
+	for _, doc := range docs {
+		rule, err := parseRule([]byte(doc))
+		if err != nil {
+			return nil, err
+		}
+		r.rules = append(r.rules, rule)
+	}
+	return r, nil
+}
+
+// Classify matches title/branch against the rule table in order and
+// returns the semver bump level of the first rule that matches, or
+// (BumpUnknown, false) if this doesn't look like a dependency update
+// any configured rule recognises.
+func (r *Registry) Classify(title, branch string) (Bump, bool) {
+	for _, rule := range r.rules {
+		from, to, ok := rule.versions(title, branch)
+		if !ok {
+			continue
+		}
+		if bump, ok := compareVersions(from, to); ok {
+			return bump, true
+		}
+	}
+	return BumpUnknown, false
+}
+
+func (r *Registry) loadEmbeddedRules() error {
+	entries, err := embeddedRules.ReadDir("embedded")
+	if err != nil {
+		return fmt.Errorf("failed to read embedded depbump rules directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+
+		content, err := embeddedRules.ReadFile("embedded/" + entry.Name())
+		if err != nil {
+			return fmt.Errorf("failed to read embedded depbump rule file %s: %w", entry.Name(), err)
+		}
+
+		rule, err := parseRule(content)
+		if err != nil {
+			return fmt.Errorf("invalid embedded depbump rule %s: %w", entry.Name(), err)
+		}
+
+		rule.Source = "embedded"
+		r.rules = append(r.rules, rule)
+	}
+
+	return nil
+}
+
+func (r *Registry) loadUserRules() error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	rulesDir := filepath.Join(homeDir, ".config", "autoprat", "depbump")
+
+	if _, err := os.Stat(rulesDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	entries, err := os.ReadDir(rulesDir)
+	if err != nil {
+		return fmt.Errorf("failed to read user depbump rules directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(rulesDir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read user depbump rule file %s: %w", entry.Name(), err)
+		}
+
+		rule, err := parseRule(content)
+		if err != nil {
+			return fmt.Errorf("invalid user depbump rule %s: %w", entry.Name(), err)
+		}
+
+		rule.Source = "user"
+		r.rules = append(r.rules, rule)
+	}
+
+	return nil
+}
+
+// parseRule unmarshals and compiles a single rule, validating that it
+// declares at least one pattern and that both compile cleanly.
+func parseRule(content []byte) (Rule, error) {
+	var rule Rule
+	if err := yaml.Unmarshal(content, &rule); err != nil {
+		return Rule{}, err
+	}
+
+	if rule.Name == "" {
+		return Rule{}, fmt.Errorf("rule name is required")
+	}
+	if rule.TitlePattern == "" && rule.BranchPattern == "" {
+		return Rule{}, fmt.Errorf("rule %q must set title_pattern or branch_pattern", rule.Name)
+	}
+
+	if rule.TitlePattern != "" {
+		re, err := regexp.Compile(rule.TitlePattern)
+		if err != nil {
+			return Rule{}, fmt.Errorf("rule %q: invalid title_pattern: %w", rule.Name, err)
+		}
+		rule.titleRe = re
+	}
+	if rule.BranchPattern != "" {
+		re, err := regexp.Compile(rule.BranchPattern)
+		if err != nil {
+			return Rule{}, fmt.Errorf("rule %q: invalid branch_pattern: %w", rule.Name, err)
+		}
+		rule.branchRe = re
+	}
+
+	return rule, nil
+}
+
+// compareVersions classifies the bump from from to to, comparing
+// dotted major.minor.patch components (ignoring a leading "v" and any
+// pre-release/build metadata suffix). Returns ok=false if either
+// version doesn't parse.
+func compareVersions(from, to string) (Bump, bool) {
+	fromParts, ok := parseVersion(from)
+	if !ok {
+		return BumpUnknown, false
+	}
+	toParts, ok := parseVersion(to)
+	if !ok {
+		return BumpUnknown, false
+	}
+
+	for i, kind := range []Bump{BumpMajor, BumpMinor, BumpPatch} {
+		if toParts[i] != fromParts[i] {
+			return kind, true
+		}
+	}
+	return BumpUnknown, false
+}
+
+// parseVersion parses "v1.2.3-rc.1+meta" into [major, minor, patch],
+// defaulting missing components to 0.
+func parseVersion(s string) ([3]int, bool) {
+	var parts [3]int
+
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+
+	segments := strings.SplitN(s, ".", 3)
+	for i, segment := range segments {
+		if segment == "" {
+			return parts, false
+		}
+		n, err := strconv.Atoi(segment)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+
+	return parts, true
+}
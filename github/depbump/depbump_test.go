@@ -0,0 +1,102 @@
+package depbump
+
+import "testing"
+
+func mustRule(t *testing.T, yamlContent string) Rule {
+	t.Helper()
+	rule, err := parseRule([]byte(yamlContent))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return rule
+}
+
+func TestParseBump(t *testing.T) {
+	cases := map[string]Bump{"patch": BumpPatch, "minor": BumpMinor, "major": BumpMajor}
+	for s, want := range cases {
+		got, ok := ParseBump(s)
+		if !ok || got != want {
+			t.Errorf("ParseBump(%q) = %v, %v; want %v, true", s, got, ok, want)
+		}
+	}
+	if _, ok := ParseBump("bogus"); ok {
+		t.Error("expected ParseBump(\"bogus\") to fail")
+	}
+}
+
+func TestClassify_DependabotPatch(t *testing.T) {
+	r := &Registry{rules: []Rule{mustRule(t, `
+name: dependabot
+title_pattern: '(?i)^chore\(deps\): bump \S+ from (?P<from>[0-9][\w.+-]*) to (?P<to>[0-9][\w.+-]*)'
+`)}}
+
+	bump, ok := r.Classify("chore(deps): bump lodash from 4.17.20 to 4.17.21", "dependabot/npm_and_yarn/lodash-4.17.21")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if bump != BumpPatch {
+		t.Errorf("expected BumpPatch, got %v", bump)
+	}
+}
+
+func TestClassify_MinorAndMajor(t *testing.T) {
+	r := &Registry{rules: []Rule{mustRule(t, `
+name: dependabot
+title_pattern: '(?i)^chore\(deps\): bump \S+ from (?P<from>[0-9][\w.+-]*) to (?P<to>[0-9][\w.+-]*)'
+`)}}
+
+	if bump, ok := r.Classify("chore(deps): bump foo from 1.2.3 to 1.3.0", ""); !ok || bump != BumpMinor {
+		t.Errorf("expected BumpMinor, got %v, %v", bump, ok)
+	}
+	if bump, ok := r.Classify("chore(deps): bump foo from 1.2.3 to 2.0.0", ""); !ok || bump != BumpMajor {
+		t.Errorf("expected BumpMajor, got %v, %v", bump, ok)
+	}
+}
+
+func TestClassify_NoMatch(t *testing.T) {
+	r := &Registry{rules: []Rule{mustRule(t, `
+name: dependabot
+title_pattern: '(?i)^chore\(deps\): bump \S+ from (?P<from>[0-9][\w.+-]*) to (?P<to>[0-9][\w.+-]*)'
+`)}}
+
+	if _, ok := r.Classify("fix: unrelated change", "feature/foo"); ok {
+		t.Error("expected no match for an unrelated PR")
+	}
+}
+
+func TestParseRule_RequiresAPattern(t *testing.T) {
+	if _, err := parseRule([]byte("name: broken\n")); err == nil {
+		t.Fatal("expected an error when neither pattern is set")
+	}
+}
+
+func TestParseRule_RejectsBadRegex(t *testing.T) {
+	if _, err := parseRule([]byte("name: broken\ntitle_pattern: '('\n")); err == nil {
+		t.Fatal("expected an error for an invalid regex")
+	}
+}
+
+func TestNewRegistryFromDocuments(t *testing.T) {
+	r, err := NewRegistryFromDocuments(`
+name: dependabot
+title_pattern: '(?i)^chore\(deps\): bump \S+ from (?P<from>[0-9][\w.+-]*) to (?P<to>[0-9][\w.+-]*)'
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bump, ok := r.Classify("chore(deps): bump lodash from 4.17.20 to 4.17.21", ""); !ok || bump != BumpPatch {
+		t.Errorf("expected BumpPatch, got %v, %v", bump, ok)
+	}
+}
+
+func TestCompareVersions_EqualVersions(t *testing.T) {
+	if bump, ok := compareVersions("1.2.3", "1.2.3"); ok {
+		t.Errorf("expected equal versions to report no bump, got %v", bump)
+	}
+}
+
+func TestCompareVersions_UnparsableVersion(t *testing.T) {
+	if _, ok := compareVersions("abc", "1.2.3"); ok {
+		t.Error("expected an unparsable version to fail")
+	}
+}
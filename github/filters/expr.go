@@ -0,0 +1,205 @@
+package filters
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/frobware/autoprat/github"
+)
+
+// compositeExpr is a node in a compiled composite filter expression.
+type compositeExpr interface {
+	eval(pr github.PullRequest) bool
+}
+
+// leafExpr wraps another filter's Matches method as a leaf reference.
+type leafExpr struct {
+	matches func(github.PullRequest) bool
+}
+
+func (e leafExpr) eval(pr github.PullRequest) bool { return e.matches(pr) }
+
+type notExpr struct{ sub compositeExpr }
+
+func (e notExpr) eval(pr github.PullRequest) bool { return !e.sub.eval(pr) }
+
+type andExpr struct{ l, r compositeExpr }
+
+func (e andExpr) eval(pr github.PullRequest) bool { return e.l.eval(pr) && e.r.eval(pr) }
+
+type orExpr struct{ l, r compositeExpr }
+
+func (e orExpr) eval(pr github.PullRequest) bool { return e.l.eval(pr) || e.r.eval(pr) }
+
+// resolveFunc resolves an identifier (another filter's flag) to a
+// compiled compositeExpr, e.g. Registry.resolveExpr.
+type resolveFunc func(ident string) (compositeExpr, error)
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+	tokenEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenizeCompositeExpr lexes expr into tokens: identifiers matching a
+// filter Flag ([a-z0-9_-]+), the keywords and/or/not (case-insensitive),
+// and parens.
+func tokenizeCompositeExpr(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+
+	isIdentRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")"})
+			i++
+		case isIdentRune(r):
+			start := i
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			switch strings.ToLower(word) {
+			case "and":
+				tokens = append(tokens, token{kind: tokenAnd, text: word})
+			case "or":
+				tokens = append(tokens, token{kind: tokenOr, text: word})
+			case "not":
+				tokens = append(tokens, token{kind: tokenNot, text: word})
+			default:
+				tokens = append(tokens, token{kind: tokenIdent, text: word})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF})
+	return tokens, nil
+}
+
+// exprParser is a recursive-descent parser for the composite filter DSL:
+//
+//	expr  := orExpr
+//	orExpr  := andExpr ("or" andExpr)*
+//	andExpr := notExpr ("and" notExpr)*
+//	notExpr := "not" notExpr | atom
+//	atom  := ident | "(" expr ")"
+type exprParser struct {
+	tokens  []token
+	pos     int
+	resolve resolveFunc
+}
+
+func parseCompositeExpr(expr string, resolve resolveFunc) (compositeExpr, error) {
+	tokens, err := tokenizeCompositeExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens, resolve: resolve}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected token %q", p.peek().text)
+	}
+	return node, nil
+}
+
+func (p *exprParser) peek() token { return p.tokens[p.pos] }
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) parseOr() (compositeExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{l: left, r: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (compositeExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{l: left, r: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (compositeExpr, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		sub, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{sub: sub}, nil
+	}
+	return p.parseAtom()
+}
+
+func (p *exprParser) parseAtom() (compositeExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenIdent:
+		return p.resolve(t.text)
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, fmt.Errorf("expected ')', got %q", p.peek().text)
+		}
+		p.next()
+		return node, nil
+	default:
+		return nil, fmt.Errorf("expected identifier or '(', got %q", t.text)
+	}
+}
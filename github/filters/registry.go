@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/frobware/autoprat/github"
+	"github.com/frobware/autoprat/github/depbump"
 	"gopkg.in/yaml.v3"
 )
 
@@ -23,7 +26,49 @@ type FilterDefinition struct {
 	Description string `yaml:"description"`
 	FilterType  string `yaml:"filter_type"`
 	Label       string `yaml:"label,omitempty"`
-	Source      string `yaml:"-"` // "embedded" or "user", not serialized.
+
+	// Expression holds a boolean DSL over other filters' flags for
+	// filter_type "composite", e.g. "not(has_lgtm) and (failing_ci or
+	// missing_approved)". Compiled into expr by Registry.compileComposites
+	// once every filter (including other composites) has loaded.
+	Expression string `yaml:"expression,omitempty"`
+
+	// Pattern is a regular expression used by filter_type
+	// "title_regex", "body_regex", and "branch_regex", compiled once
+	// at load time into re.
+	Pattern string `yaml:"pattern,omitempty"`
+
+	// Paths is a list of doublestar glob patterns used by filter_type
+	// "files_changed_glob", matched against PullRequest.ChangedFiles.
+	Paths []string `yaml:"paths,omitempty"`
+
+	// All requires every changed file to match one of Paths, instead
+	// of the default "any file matches". Only meaningful for
+	// "files_changed_glob".
+	All bool `yaml:"all,omitempty"`
+
+	// Invert flips the match result, e.g. to express "title does NOT
+	// match pattern".
+	Invert bool `yaml:"invert,omitempty"`
+
+	// BumpLevel is the semver bump level ("patch", "minor", or
+	// "major") filter_type "version_bump" matches against, classified
+	// via depbump.Registry.Classify.
+	BumpLevel string `yaml:"bump_level,omitempty"`
+
+	Source string `yaml:"-"` // "embedded", "user", or "project"; not serialized.
+
+	// expr is the compiled form of Expression, populated by
+	// Registry.compileComposites. Nil for non-composite filters.
+	expr compositeExpr
+
+	// re is the compiled form of Pattern, populated by validateFilter.
+	// Nil for filter types that don't use Pattern.
+	re *regexp.Regexp
+
+	// depbump recognises dependency-update PRs for filter_type
+	// "version_bump", populated by validateFilter.
+	depbump *depbump.Registry
 }
 
 // FilterType represents the type of filter logic to apply.
@@ -33,6 +78,12 @@ const (
 	FilterTypeLabelAbsence FilterType = iota
 	FilterTypeLabelPresence
 	FilterTypeFailingCI
+	FilterTypeComposite
+	FilterTypeTitleRegex
+	FilterTypeBodyRegex
+	FilterTypeBranchRegex
+	FilterTypeFilesChangedGlob
+	FilterTypeVersionBump
 )
 
 // ToFilterType converts a string filter type to the enum value.
@@ -44,6 +95,18 @@ func ToFilterType(s string) FilterType {
 		return FilterTypeLabelPresence
 	case "failing_ci":
 		return FilterTypeFailingCI
+	case "composite":
+		return FilterTypeComposite
+	case "title_regex":
+		return FilterTypeTitleRegex
+	case "body_regex":
+		return FilterTypeBodyRegex
+	case "branch_regex":
+		return FilterTypeBranchRegex
+	case "files_changed_glob":
+		return FilterTypeFilesChangedGlob
+	case "version_bump":
+		return FilterTypeVersionBump
 	default:
 		return FilterTypeLabelAbsence
 	}
@@ -51,56 +114,105 @@ func ToFilterType(s string) FilterType {
 
 // Apply applies this filter to a slice of PRs and returns the filtered results.
 func (fd FilterDefinition) Apply(prs []github.PullRequest) []github.PullRequest {
+	filtered := prs[:0]
+	for _, pr := range prs {
+		if fd.Matches(pr) {
+			filtered = append(filtered, pr)
+		}
+	}
+	return filtered
+}
+
+// Matches reports whether pr satisfies this filter. Composite filters
+// delegate to their compiled expr; see Registry.compileComposites.
+func (fd FilterDefinition) Matches(pr github.PullRequest) bool {
 	switch ToFilterType(fd.FilterType) {
 	case FilterTypeLabelAbsence:
-		return filterByLabelAbsence(prs, fd.Label)
+		return !slices.Contains(pr.Labels, fd.Label)
 	case FilterTypeLabelPresence:
-		return filterByLabelPresence(prs, fd.Label)
+		return slices.Contains(pr.Labels, fd.Label)
 	case FilterTypeFailingCI:
-		return filterByFailingCI(prs)
+		return hasFailingCI(pr)
+	case FilterTypeTitleRegex:
+		return fd.withInvert(fd.re != nil && fd.re.MatchString(pr.Title))
+	case FilterTypeBodyRegex:
+		return fd.withInvert(fd.re != nil && fd.re.MatchString(pr.Body))
+	case FilterTypeBranchRegex:
+		return fd.withInvert(fd.re != nil && fd.re.MatchString(pr.HeadRefName))
+	case FilterTypeFilesChangedGlob:
+		return fd.withInvert(matchesChangedFiles(fd.Paths, fd.All, pr.ChangedFiles))
+	case FilterTypeVersionBump:
+		return fd.withInvert(fd.depbump != nil && matchesBumpLevel(fd.depbump, fd.BumpLevel, pr))
+	case FilterTypeComposite:
+		return fd.expr != nil && fd.expr.eval(pr)
 	default:
-		return prs
+		return false
 	}
 }
 
-// filterByLabelAbsence returns PRs that don't have the specified label.
-func filterByLabelAbsence(prs []github.PullRequest, label string) []github.PullRequest {
-	filtered := prs[:0]
-	for _, pr := range prs {
-		if !slices.Contains(pr.Labels, label) {
-			filtered = append(filtered, pr)
+// withInvert flips matched when Invert is set.
+func (fd FilterDefinition) withInvert(matched bool) bool {
+	if fd.Invert {
+		return !matched
+	}
+	return matched
+}
+
+// matchesChangedFiles reports whether changedFiles satisfies patterns:
+// by default a PR matches if any changed file matches any pattern; with
+// all set, every changed file must match at least one pattern.
+func matchesChangedFiles(patterns []string, all bool, changedFiles []string) bool {
+	if all {
+		if len(changedFiles) == 0 {
+			return false
+		}
+		for _, path := range changedFiles {
+			if !matchesAnyGlob(patterns, path) {
+				return false
+			}
 		}
+		return true
 	}
-	return filtered
+
+	for _, path := range changedFiles {
+		if matchesAnyGlob(patterns, path) {
+			return true
+		}
+	}
+	return false
 }
 
-// filterByLabelPresence returns PRs that have the specified label.
-func filterByLabelPresence(prs []github.PullRequest, label string) []github.PullRequest {
-	filtered := prs[:0]
-	for _, pr := range prs {
-		if slices.Contains(pr.Labels, label) {
-			filtered = append(filtered, pr)
+func matchesAnyGlob(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if ok, err := doublestar.Match(pattern, path); err == nil && ok {
+			return true
 		}
 	}
-	return filtered
+	return false
 }
 
-// filterByFailingCI returns PRs that have failing CI checks.
-func filterByFailingCI(prs []github.PullRequest) []github.PullRequest {
-	filtered := prs[:0]
-	for _, pr := range prs {
-		for _, check := range pr.StatusCheckRollup.Contexts.Nodes {
-			status := check.State
-			if status == "" {
-				status = check.Conclusion
-			}
-			if status == "FAILURE" {
-				filtered = append(filtered, pr)
-				break
-			}
+// matchesBumpLevel classifies pr's title/branch as a dependency update
+// via registry and reports whether it matches level.
+func matchesBumpLevel(registry *depbump.Registry, level string, pr github.PullRequest) bool {
+	bump, ok := registry.Classify(pr.Title, pr.HeadRefName)
+	if !ok {
+		return false
+	}
+	return bump.String() == level
+}
+
+// hasFailingCI reports whether pr has any status check in a failing state.
+func hasFailingCI(pr github.PullRequest) bool {
+	for _, check := range pr.StatusCheckRollup.Contexts.Nodes {
+		status := check.State
+		if status == "" {
+			status = check.Conclusion
+		}
+		if status == "FAILURE" {
+			return true
 		}
 	}
-	return filtered
+	return false
 }
 
 // Registry holds all available filters loaded from embedded and user sources.
@@ -122,6 +234,14 @@ func NewRegistry() (*Registry, error) {
 		fmt.Fprintf(os.Stderr, "Warning: failed to load user filters: %v\n", err)
 	}
 
+	if err := r.loadProjectFilters(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load project filters: %v\n", err)
+	}
+
+	if err := r.compileComposites(); err != nil {
+		return nil, fmt.Errorf("failed to compile composite filters: %w", err)
+	}
+
 	return r, nil
 }
 
@@ -147,7 +267,7 @@ func (r *Registry) loadEmbeddedFilters() error {
 			return fmt.Errorf("failed to parse embedded filter file %s: %w", entry.Name(), err)
 		}
 
-		if err := r.validateFilter(filter); err != nil {
+		if err := r.validateFilter(&filter); err != nil {
 			return fmt.Errorf("invalid embedded filter %s: %w", entry.Name(), err)
 		}
 
@@ -191,7 +311,7 @@ func (r *Registry) loadUserFilters() error {
 			return fmt.Errorf("failed to parse user filter file %s: %w", entry.Name(), err)
 		}
 
-		if err := r.validateFilter(filter); err != nil {
+		if err := r.validateFilter(&filter); err != nil {
 			return fmt.Errorf("invalid user filter %s: %w", entry.Name(), err)
 		}
 
@@ -202,8 +322,72 @@ func (r *Registry) loadUserFilters() error {
 	return nil
 }
 
-// validateFilter ensures a filter definition is valid.
-func (r *Registry) validateFilter(filter FilterDefinition) error {
+// loadProjectFilters loads filters that a repo ships for itself,
+// discovered by walking up from $PWD for a ".autoprat/filters/*.yaml"
+// directory and/or a ".autoprat.yaml" file with a top-level
+// "filters:" list. Project filters override user and embedded ones of
+// the same flag, and are tagged Source "project" so GetFlagsBySource
+// can report where a flag came from.
+func (r *Registry) loadProjectFilters() error {
+	projectDir, ok := findProjectDir()
+	if !ok {
+		return nil
+	}
+
+	filtersDir := filepath.Join(projectDir, ".autoprat", "filters")
+	if entries, err := os.ReadDir(filtersDir); err == nil {
+		for _, entry := range entries {
+			if !strings.HasSuffix(entry.Name(), ".yaml") {
+				continue
+			}
+
+			content, err := os.ReadFile(filepath.Join(filtersDir, entry.Name()))
+			if err != nil {
+				return fmt.Errorf("failed to read project filter file %s: %w", entry.Name(), err)
+			}
+
+			var filter FilterDefinition
+			if err := yaml.Unmarshal(content, &filter); err != nil {
+				return fmt.Errorf("failed to parse project filter file %s: %w", entry.Name(), err)
+			}
+
+			if err := r.validateFilter(&filter); err != nil {
+				return fmt.Errorf("invalid project filter %s: %w", entry.Name(), err)
+			}
+
+			filter.Source = "project"
+			r.filters[filter.Flag] = filter
+		}
+	}
+
+	configFile := filepath.Join(projectDir, ".autoprat.yaml")
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil
+	}
+
+	var doc struct {
+		Filters []FilterDefinition `yaml:"filters"`
+	}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", configFile, err)
+	}
+
+	for _, filter := range doc.Filters {
+		if err := r.validateFilter(&filter); err != nil {
+			return fmt.Errorf("invalid filter %q in %s: %w", filter.Name, configFile, err)
+		}
+		filter.Source = "project"
+		r.filters[filter.Flag] = filter
+	}
+
+	return nil
+}
+
+// validateFilter ensures a filter definition is valid, compiling its
+// Pattern regex (if any) once and caching it on filter so Matches
+// never recompiles per PR.
+func (r *Registry) validateFilter(filter *FilterDefinition) error {
 	if filter.Name == "" {
 		return fmt.Errorf("filter name is required")
 	}
@@ -217,7 +401,11 @@ func (r *Registry) validateFilter(filter FilterDefinition) error {
 		return fmt.Errorf("filter type is required")
 	}
 
-	validTypes := []string{"label_absence", "label_presence", "failing_ci"}
+	validTypes := []string{
+		"label_absence", "label_presence", "failing_ci", "composite",
+		"title_regex", "body_regex", "branch_regex", "files_changed_glob",
+		"version_bump",
+	}
 	if !slices.Contains(validTypes, filter.FilterType) {
 		return fmt.Errorf("invalid filter type %q, must be one of: %s", filter.FilterType, strings.Join(validTypes, ", "))
 	}
@@ -226,9 +414,95 @@ func (r *Registry) validateFilter(filter FilterDefinition) error {
 		return fmt.Errorf("label is required for filter type %q", filter.FilterType)
 	}
 
+	if filter.FilterType == "composite" && filter.Expression == "" {
+		return fmt.Errorf("expression is required for filter type %q", filter.FilterType)
+	}
+
+	switch filter.FilterType {
+	case "title_regex", "body_regex", "branch_regex":
+		if filter.Pattern == "" {
+			return fmt.Errorf("pattern is required for filter type %q", filter.FilterType)
+		}
+		re, err := regexp.Compile(filter.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q for filter type %q: %w", filter.Pattern, filter.FilterType, err)
+		}
+		filter.re = re
+	case "files_changed_glob":
+		if len(filter.Paths) == 0 {
+			return fmt.Errorf("paths is required for filter type %q", filter.FilterType)
+		}
+		for _, pattern := range filter.Paths {
+			if !doublestar.ValidatePattern(pattern) {
+				return fmt.Errorf("invalid glob pattern %q for filter type %q", pattern, filter.FilterType)
+			}
+		}
+	case "version_bump":
+		if _, ok := depbump.ParseBump(filter.BumpLevel); !ok {
+			return fmt.Errorf("invalid bump_level %q for filter type %q, must be one of: patch, minor, major", filter.BumpLevel, filter.FilterType)
+		}
+		registry, err := depbump.NewRegistry()
+		if err != nil {
+			return fmt.Errorf("failed to load depbump rules for filter type %q: %w", filter.FilterType, err)
+		}
+		filter.depbump = registry
+	}
+
+	return nil
+}
+
+// compileComposites parses and resolves every composite filter's
+// Expression once all filters (including other composites) have
+// loaded, so identifiers can reference any filter regardless of load
+// order, and cycles/missing references are reported up front rather
+// than at Apply time.
+func (r *Registry) compileComposites() error {
+	visiting := make(map[string]bool)
+	for flag, fd := range r.filters {
+		if fd.FilterType != "composite" {
+			continue
+		}
+		if _, err := r.resolveExpr(flag, visiting); err != nil {
+			return fmt.Errorf("composite filter %q: %w", flag, err)
+		}
+	}
 	return nil
 }
 
+// resolveExpr compiles the composite filter named flag into a
+// compositeExpr, caching the result on the registry's copy of the
+// FilterDefinition. Non-composite filters resolve to a leaf node
+// wrapping Matches. visiting detects reference cycles across composites.
+func (r *Registry) resolveExpr(flag string, visiting map[string]bool) (compositeExpr, error) {
+	fd, exists := r.filters[flag]
+	if !exists {
+		return nil, fmt.Errorf("unknown filter %q", flag)
+	}
+
+	if fd.FilterType != "composite" {
+		return leafExpr{matches: fd.Matches}, nil
+	}
+	if fd.expr != nil {
+		return fd.expr, nil
+	}
+	if visiting[flag] {
+		return nil, fmt.Errorf("cycle detected at %q", flag)
+	}
+	visiting[flag] = true
+	defer delete(visiting, flag)
+
+	expr, err := parseCompositeExpr(fd.Expression, func(ident string) (compositeExpr, error) {
+		return r.resolveExpr(ident, visiting)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fd.expr = expr
+	r.filters[flag] = fd
+	return expr, nil
+}
+
 // GetFilter returns the filter definition for the given flag name.
 func (r *Registry) GetFilter(flag string) (FilterDefinition, bool) {
 	filter, exists := r.filters[flag]
@@ -257,7 +531,8 @@ func (r *Registry) GetFlags() []string {
 	return flags
 }
 
-// GetFlagsBySource returns filter flag names for a specific source, sorted.
+// GetFlagsBySource returns filter flag names for a specific source
+// ("embedded", "user", or "project"), sorted.
 func (r *Registry) GetFlagsBySource(source string) []string {
 	var flags []string
 	for flag, filter := range r.filters {
@@ -275,3 +550,29 @@ func (r *Registry) GetFlagsBySource(source string) []string {
 	}
 	return flags
 }
+
+// findProjectDir walks upward from $PWD looking for a ".autoprat.yaml"
+// file or ".autoprat" directory, returning the first directory that
+// has either. This lets a repo ship its own filters/actions/templates
+// from any subdirectory of the checkout, the way .git is discovered.
+func findProjectDir() (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".autoprat.yaml")); err == nil {
+			return dir, true
+		}
+		if info, err := os.Stat(filepath.Join(dir, ".autoprat")); err == nil && info.IsDir() {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
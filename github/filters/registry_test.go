@@ -0,0 +1,409 @@
+package filters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/frobware/autoprat/github"
+)
+
+func newTestRegistry(defs ...FilterDefinition) *Registry {
+	r := &Registry{filters: make(map[string]FilterDefinition)}
+	for _, fd := range defs {
+		r.filters[fd.Flag] = fd
+	}
+	return r
+}
+
+func TestMatches_LabelAbsence(t *testing.T) {
+	fd := FilterDefinition{Flag: "no-lgtm", FilterType: "label_absence", Label: "lgtm"}
+	pr := github.PullRequest{Labels: []string{"approved"}}
+	if !fd.Matches(pr) {
+		t.Error("expected PR without \"lgtm\" label to match")
+	}
+	pr.Labels = append(pr.Labels, "lgtm")
+	if fd.Matches(pr) {
+		t.Error("expected PR with \"lgtm\" label not to match")
+	}
+}
+
+func TestMatches_LabelPresence(t *testing.T) {
+	fd := FilterDefinition{Flag: "has-lgtm", FilterType: "label_presence", Label: "lgtm"}
+	pr := github.PullRequest{Labels: []string{"lgtm"}}
+	if !fd.Matches(pr) {
+		t.Error("expected PR with \"lgtm\" label to match")
+	}
+	pr.Labels = nil
+	if fd.Matches(pr) {
+		t.Error("expected PR without \"lgtm\" label not to match")
+	}
+}
+
+func TestMatches_FailingCI(t *testing.T) {
+	fd := FilterDefinition{Flag: "failing-ci", FilterType: "failing_ci"}
+	pr := github.PullRequest{}
+	pr.StatusCheckRollup.Contexts.Nodes = []github.StatusCheck{{State: "FAILURE"}}
+	if !fd.Matches(pr) {
+		t.Error("expected PR with a failing check to match")
+	}
+	pr.StatusCheckRollup.Contexts.Nodes = []github.StatusCheck{{State: "SUCCESS"}}
+	if fd.Matches(pr) {
+		t.Error("expected PR without a failing check not to match")
+	}
+}
+
+func TestApply_FiltersSlice(t *testing.T) {
+	fd := FilterDefinition{Flag: "has-lgtm", FilterType: "label_presence", Label: "lgtm"}
+	prs := []github.PullRequest{
+		{Number: 1, Labels: []string{"lgtm"}},
+		{Number: 2, Labels: []string{"approved"}},
+	}
+	got := fd.Apply(prs)
+	if len(got) != 1 || got[0].Number != 1 {
+		t.Errorf("expected only PR #1 to survive, got %+v", got)
+	}
+}
+
+func TestMatches_TitleRegex(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "chore-deps", Description: "d", FilterType: "title_regex", Pattern: `^chore\(deps\)`}
+	if err := r.validateFilter(&fd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !fd.Matches(github.PullRequest{Title: "chore(deps): bump foo"}) {
+		t.Error("expected matching title to match")
+	}
+	if fd.Matches(github.PullRequest{Title: "fix: bump foo"}) {
+		t.Error("expected non-matching title not to match")
+	}
+}
+
+func TestMatches_TitleRegex_Invert(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "not-chore-deps", Description: "d", FilterType: "title_regex", Pattern: `^chore\(deps\)`, Invert: true}
+	if err := r.validateFilter(&fd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fd.Matches(github.PullRequest{Title: "chore(deps): bump foo"}) {
+		t.Error("expected matching title not to match when inverted")
+	}
+	if !fd.Matches(github.PullRequest{Title: "fix: bump foo"}) {
+		t.Error("expected non-matching title to match when inverted")
+	}
+}
+
+func TestMatches_BodyRegex(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "breaking", Description: "d", FilterType: "body_regex", Pattern: `BREAKING CHANGE`}
+	if err := r.validateFilter(&fd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !fd.Matches(github.PullRequest{Body: "this has a BREAKING CHANGE"}) {
+		t.Error("expected matching body to match")
+	}
+	if fd.Matches(github.PullRequest{Body: "no issues here"}) {
+		t.Error("expected non-matching body not to match")
+	}
+}
+
+func TestMatches_BranchRegex(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "renovate", Description: "d", FilterType: "branch_regex", Pattern: `^renovate/`}
+	if err := r.validateFilter(&fd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !fd.Matches(github.PullRequest{HeadRefName: "renovate/go-deps"}) {
+		t.Error("expected matching branch to match")
+	}
+	if fd.Matches(github.PullRequest{HeadRefName: "main"}) {
+		t.Error("expected non-matching branch not to match")
+	}
+}
+
+func TestMatches_FilesChangedGlob_Any(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "touches-vendor", Description: "d", FilterType: "files_changed_glob", Paths: []string{"vendor/**"}}
+	if err := r.validateFilter(&fd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !fd.Matches(github.PullRequest{ChangedFiles: []string{"README.md", "vendor/foo/bar.go"}}) {
+		t.Error("expected a PR touching vendor/** to match")
+	}
+	if fd.Matches(github.PullRequest{ChangedFiles: []string{"README.md"}}) {
+		t.Error("expected a PR not touching vendor/** not to match")
+	}
+}
+
+func TestMatches_FilesChangedGlob_All(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "only-docs", Description: "d", FilterType: "files_changed_glob", Paths: []string{"docs/**", "*.md"}, All: true}
+	if err := r.validateFilter(&fd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !fd.Matches(github.PullRequest{ChangedFiles: []string{"README.md", "docs/guide.md"}}) {
+		t.Error("expected a PR touching only docs/markdown to match with all:true")
+	}
+	if fd.Matches(github.PullRequest{ChangedFiles: []string{"README.md", "main.go"}}) {
+		t.Error("expected a PR touching a non-matching file not to match with all:true")
+	}
+}
+
+func TestValidateFilter_RejectsBadPattern(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "f", Description: "d", FilterType: "title_regex", Pattern: "("}
+	if err := r.validateFilter(&fd); err == nil {
+		t.Fatal("expected invalid regex to be rejected")
+	}
+}
+
+func TestValidateFilter_RequiresPathsForFilesChangedGlob(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "f", Description: "d", FilterType: "files_changed_glob"}
+	if err := r.validateFilter(&fd); err == nil {
+		t.Fatal("expected missing paths to be rejected")
+	}
+}
+
+func TestMatches_VersionBump(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "dep-patch-only", Description: "d", FilterType: "version_bump", BumpLevel: "patch"}
+	if err := r.validateFilter(&fd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	patchPR := github.PullRequest{Title: "chore(deps): bump lodash from 4.17.20 to 4.17.21"}
+	if !fd.Matches(patchPR) {
+		t.Error("expected a patch bump to match bump_level: patch")
+	}
+
+	minorPR := github.PullRequest{Title: "chore(deps): bump lodash from 4.17.20 to 4.18.0"}
+	if fd.Matches(minorPR) {
+		t.Error("expected a minor bump not to match bump_level: patch")
+	}
+
+	unrelatedPR := github.PullRequest{Title: "fix: unrelated change"}
+	if fd.Matches(unrelatedPR) {
+		t.Error("expected a non-dependency-update PR not to match")
+	}
+}
+
+func TestValidateFilter_RejectsBadBumpLevel(t *testing.T) {
+	r := &Registry{}
+	fd := FilterDefinition{Name: "n", Flag: "f", Description: "d", FilterType: "version_bump", BumpLevel: "bogus"}
+	if err := r.validateFilter(&fd); err == nil {
+		t.Fatal("expected invalid bump_level to be rejected")
+	}
+}
+
+func TestComposite_And(t *testing.T) {
+	r := newTestRegistry(
+		FilterDefinition{Flag: "has-lgtm", FilterType: "label_presence", Label: "lgtm"},
+		FilterDefinition{Flag: "failing-ci", FilterType: "failing_ci"},
+		FilterDefinition{Flag: "both", FilterType: "composite", Expression: "has-lgtm and failing-ci"},
+	)
+	if err := r.compileComposites(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fd, _ := r.GetFilter("both")
+
+	pr := github.PullRequest{Labels: []string{"lgtm"}}
+	pr.StatusCheckRollup.Contexts.Nodes = []github.StatusCheck{{State: "FAILURE"}}
+	if !fd.Matches(pr) {
+		t.Error("expected lgtm+failing PR to match \"and\" composite")
+	}
+
+	pr.Labels = nil
+	if fd.Matches(pr) {
+		t.Error("expected PR without lgtm not to match \"and\" composite")
+	}
+}
+
+func TestComposite_OrNot(t *testing.T) {
+	r := newTestRegistry(
+		FilterDefinition{Flag: "has-lgtm", FilterType: "label_presence", Label: "lgtm"},
+		FilterDefinition{Flag: "failing-ci", FilterType: "failing_ci"},
+		FilterDefinition{Flag: "needs-attention", FilterType: "composite", Expression: "not(has-lgtm) or failing-ci"},
+	)
+	if err := r.compileComposites(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fd, _ := r.GetFilter("needs-attention")
+
+	pr := github.PullRequest{}
+	if !fd.Matches(pr) {
+		t.Error("expected PR without lgtm to match \"not(has-lgtm) or failing-ci\"")
+	}
+
+	pr.Labels = []string{"lgtm"}
+	if fd.Matches(pr) {
+		t.Error("expected lgtm PR without failing CI not to match")
+	}
+
+	pr.StatusCheckRollup.Contexts.Nodes = []github.StatusCheck{{State: "FAILURE"}}
+	if !fd.Matches(pr) {
+		t.Error("expected lgtm PR with failing CI to match via the \"or\" branch")
+	}
+}
+
+func TestComposite_ReferencesAnotherComposite(t *testing.T) {
+	r := newTestRegistry(
+		FilterDefinition{Flag: "has-lgtm", FilterType: "label_presence", Label: "lgtm"},
+		FilterDefinition{Flag: "failing-ci", FilterType: "failing_ci"},
+		FilterDefinition{Flag: "needs-attention", FilterType: "composite", Expression: "not(has-lgtm) or failing-ci"},
+		FilterDefinition{Flag: "urgent", FilterType: "composite", Expression: "needs-attention and failing-ci"},
+	)
+	if err := r.compileComposites(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fd, _ := r.GetFilter("urgent")
+
+	pr := github.PullRequest{}
+	pr.StatusCheckRollup.Contexts.Nodes = []github.StatusCheck{{State: "FAILURE"}}
+	if !fd.Matches(pr) {
+		t.Error("expected PR with failing CI and no lgtm to match \"urgent\"")
+	}
+}
+
+func TestComposite_DetectsCycle(t *testing.T) {
+	r := newTestRegistry(
+		FilterDefinition{Flag: "a", FilterType: "composite", Expression: "b"},
+		FilterDefinition{Flag: "b", FilterType: "composite", Expression: "a"},
+	)
+	if err := r.compileComposites(); err == nil {
+		t.Fatal("expected cycle to be reported")
+	}
+}
+
+func TestComposite_UnknownReference(t *testing.T) {
+	r := newTestRegistry(
+		FilterDefinition{Flag: "a", FilterType: "composite", Expression: "does-not-exist"},
+	)
+	if err := r.compileComposites(); err == nil {
+		t.Fatal("expected unknown reference to be reported")
+	}
+}
+
+func TestParseCompositeExpr_OperatorPrecedence(t *testing.T) {
+	calls := map[string]bool{"x": true, "y": false, "z": false}
+	resolve := func(ident string) (compositeExpr, error) {
+		v := calls[ident]
+		return leafExpr{matches: func(github.PullRequest) bool { return v }}, nil
+	}
+
+	// "and" binds tighter than "or": x or y and z == x or (y and z) == true.
+	expr, err := parseCompositeExpr("x or y and z", resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !expr.eval(github.PullRequest{}) {
+		t.Error("expected \"x or y and z\" to evaluate true via the \"x\" branch")
+	}
+}
+
+func TestParseCompositeExpr_UnexpectedToken(t *testing.T) {
+	resolve := func(ident string) (compositeExpr, error) {
+		return leafExpr{matches: func(github.PullRequest) bool { return true }}, nil
+	}
+	if _, err := parseCompositeExpr("a and", resolve); err == nil {
+		t.Fatal("expected trailing \"and\" to be rejected")
+	}
+	if _, err := parseCompositeExpr("a b", resolve); err == nil {
+		t.Fatal("expected two adjacent identifiers to be rejected")
+	}
+}
+
+func TestLoadProjectFilters_AutopratYAML(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte(`
+defaults:
+  search_query: "is:open"
+
+filters:
+  - name: project-hold
+    flag: project-hold
+    description: Has the project's hold label
+    filter_type: label_presence
+    label: hold
+`)
+	if err := os.WriteFile(filepath.Join(dir, ".autoprat.yaml"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	restore := chdir(t, dir)
+	defer restore()
+
+	r := &Registry{filters: make(map[string]FilterDefinition)}
+	if err := r.loadProjectFilters(); err != nil {
+		t.Fatalf("loadProjectFilters failed: %v", err)
+	}
+
+	fd, ok := r.GetFilter("project-hold")
+	if !ok {
+		t.Fatal("expected project-hold filter to be loaded")
+	}
+	if fd.Source != "project" {
+		t.Errorf("Source = %q, want %q", fd.Source, "project")
+	}
+	if got := r.GetFlagsBySource("project"); len(got) != 1 || got[0] != "project-hold" {
+		t.Errorf("GetFlagsBySource(\"project\") = %v, want [project-hold]", got)
+	}
+}
+
+func TestLoadProjectFilters_DotAutopratDirectory(t *testing.T) {
+	dir := t.TempDir()
+	filtersDir := filepath.Join(dir, ".autoprat", "filters")
+	if err := os.MkdirAll(filtersDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := []byte(`
+name: project-ci
+flag: project-ci
+description: Failing CI
+filter_type: failing_ci
+`)
+	if err := os.WriteFile(filepath.Join(filtersDir, "ci.yaml"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Run from a nested subdirectory to exercise the upward walk.
+	subdir := filepath.Join(dir, "a", "b")
+	if err := os.MkdirAll(subdir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	restore := chdir(t, subdir)
+	defer restore()
+
+	r := &Registry{filters: make(map[string]FilterDefinition)}
+	if err := r.loadProjectFilters(); err != nil {
+		t.Fatalf("loadProjectFilters failed: %v", err)
+	}
+
+	if _, ok := r.GetFilter("project-ci"); !ok {
+		t.Fatal("expected project-ci filter to be discovered from a nested subdirectory")
+	}
+}
+
+// chdir switches the working directory for the duration of a test and
+// returns a restore func, since t.Chdir isn't available on every Go
+// version this repo targets.
+func chdir(t *testing.T, dir string) func() {
+	t.Helper()
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	return func() { os.Chdir(orig) }
+}
@@ -4,8 +4,6 @@ import (
 	"strings"
 
 	_ "embed"
-
-	"github.com/cli/go-gh"
 )
 
 //go:embed queries/search-prs.graphql
@@ -13,16 +11,35 @@ var searchPRQuery string
 
 // graphQLPullRequest matches the GraphQL query response structure.
 type graphQLPullRequest struct {
-	Number            int               `json:"number"`
-	Title             string            `json:"title"`
-	HeadRefName       string            `json:"headRefName"`
-	CreatedAt         string            `json:"createdAt"`
-	State             string            `json:"state"`
-	Author            author            `json:"author"`
-	Labels            labels            `json:"labels"`
-	URL               string            `json:"url"`
-	StatusCheckRollup StatusCheckRollup `json:"statusCheckRollup"`
-	Comments          comments          `json:"comments"`
+	Number              int               `json:"number"`
+	Title               string            `json:"title"`
+	Body                string            `json:"body"`
+	HeadRefName         string            `json:"headRefName"`
+	CreatedAt           string            `json:"createdAt"`
+	State               string            `json:"state"`
+	Author              author            `json:"author"`
+	Labels              labels            `json:"labels"`
+	URL                 string            `json:"url"`
+	Mergeable           string            `json:"mergeable"`
+	MergeStateStatus    string            `json:"mergeStateStatus"`
+	IsInMergeQueue      bool              `json:"isInMergeQueue"`
+	IsMergeQueueEnabled bool              `json:"isMergeQueueEnabled"`
+	BaseRef             baseRef           `json:"baseRef"`
+	StatusCheckRollup   StatusCheckRollup `json:"statusCheckRollup"`
+	Comments            comments          `json:"comments"`
+	Files               changedFiles      `json:"files"`
+}
+
+type changedFiles struct {
+	Nodes []struct {
+		Path string `json:"path"`
+	} `json:"nodes"`
+}
+
+type baseRef struct {
+	BranchProtectionRule struct {
+		RequiredStatusCheckContexts []string `json:"requiredStatusCheckContexts"`
+	} `json:"branchProtectionRule"`
 }
 
 type comments struct {
@@ -39,53 +56,16 @@ type labels struct {
 	} `json:"nodes"`
 }
 
+// searchPullRequests runs query against the default go-gh client. It's
+// a thin wrapper over Searcher.Search for existing callers (client.go)
+// that don't need client injection; tests and GHE users
+// should construct a Searcher directly.
 func searchPullRequests(query string) ([]PullRequest, error) {
-	client, err := gh.GQLClient(nil)
+	searcher, err := NewSearcher()
 	if err != nil {
 		return nil, err
 	}
-
-	vars := map[string]any{
-		"query": query,
-	}
-
-	var resp struct {
-		Search struct {
-			Nodes []graphQLPullRequest `json:"nodes"`
-		} `json:"search"`
-	}
-
-	if err := client.Do(searchPRQuery, vars, &resp); err != nil {
-		return nil, err
-	}
-
-	prs := make([]PullRequest, 0, len(resp.Search.Nodes))
-	for _, gqlPR := range resp.Search.Nodes {
-		labelNames := make([]string, 0, len(gqlPR.Labels.Nodes))
-		for _, label := range gqlPR.Labels.Nodes {
-			labelNames = append(labelNames, label.Name)
-		}
-
-		// Extract repo from URL since search doesn't include repo context
-		repo := extractRepoFromURL(gqlPR.URL)
-
-		pr := PullRequest{
-			Number:            gqlPR.Number,
-			Title:             gqlPR.Title,
-			HeadRefName:       gqlPR.HeadRefName,
-			CreatedAt:         gqlPR.CreatedAt,
-			State:             gqlPR.State,
-			Labels:            labelNames,
-			AuthorLogin:       gqlPR.Author.Login,
-			URL:               gqlPR.URL,
-			StatusCheckRollup: gqlPR.StatusCheckRollup,
-			Comments:          gqlPR.Comments.Nodes,
-			repo:              repo,
-		}
-		prs = append(prs, pr)
-	}
-
-	return prs, nil
+	return searcher.Search(query)
 }
 
 func extractRepoFromURL(url string) string {
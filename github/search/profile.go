@@ -0,0 +1,107 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+// TemplateStats summarises how often a template was built, the
+// cumulative wall time spent in BuildQuery, and the total size of the
+// queries it produced.
+type TemplateStats struct {
+	Count           int64
+	TotalDuration   time.Duration
+	TotalResultSize int64
+}
+
+// templateCounter holds TemplateStats as atomics so BuildQuery can
+// record from concurrent callers without a lock on the hot path.
+type templateCounter struct {
+	count    atomic.Int64
+	duration atomic.Int64 // nanoseconds
+	size     atomic.Int64
+}
+
+// profilingEnv is the environment variable that turns on profiling
+// without requiring a --profile flag.
+const profilingEnv = "AUTOPRAT_PROFILE"
+
+// EnableProfiling turns on per-template execution stats, as if
+// AUTOPRAT_PROFILE=1 had been set. A future --profile CLI flag should
+// call this after constructing the registry.
+func (r *TemplateRegistry) EnableProfiling() {
+	r.profiling = true
+}
+
+// ProfilingEnabled reports whether stats are being recorded, either via
+// EnableProfiling or the AUTOPRAT_PROFILE=1 environment variable.
+func (r *TemplateRegistry) ProfilingEnabled() bool {
+	return r.profiling
+}
+
+func (r *TemplateRegistry) recordQuery(flag string, d time.Duration, resultSize int) {
+	if !r.profiling {
+		return
+	}
+
+	r.statsMu.Lock()
+	c, ok := r.stats[flag]
+	if !ok {
+		c = &templateCounter{}
+		r.stats[flag] = c
+	}
+	r.statsMu.Unlock()
+
+	c.count.Add(1)
+	c.duration.Add(int64(d))
+	c.size.Add(int64(resultSize))
+}
+
+// Stats returns a snapshot of the execution stats recorded so far, keyed
+// by template flag. Empty unless profiling is enabled.
+func (r *TemplateRegistry) Stats() map[string]TemplateStats {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+
+	snapshot := make(map[string]TemplateStats, len(r.stats))
+	for flag, c := range r.stats {
+		snapshot[flag] = TemplateStats{
+			Count:           c.count.Load(),
+			TotalDuration:   time.Duration(c.duration.Load()),
+			TotalResultSize: c.size.Load(),
+		}
+	}
+	return snapshot
+}
+
+// PrintStats writes a table of Stats() to w, sorted by cumulative
+// duration descending. Intended to run on exit when profiling is
+// enabled.
+func (r *TemplateRegistry) PrintStats(w io.Writer) {
+	stats := r.Stats()
+
+	flags := make([]string, 0, len(stats))
+	for flag := range stats {
+		flags = append(flags, flag)
+	}
+	slices.SortFunc(flags, func(a, b string) int {
+		return int(stats[b].TotalDuration - stats[a].TotalDuration)
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TEMPLATE\tCOUNT\tTOTAL TIME\tTOTAL SIZE")
+	for _, flag := range flags {
+		s := stats[flag]
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\n", flag, s.Count, s.TotalDuration, s.TotalResultSize)
+	}
+	tw.Flush()
+}
+
+func profilingFromEnv() bool {
+	return os.Getenv(profilingEnv) == "1"
+}
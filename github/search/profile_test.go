@@ -0,0 +1,103 @@
+package search
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBuildQuery_RecordsStatsWhenProfiling(t *testing.T) {
+	r := newTestRegistry()
+	r.stats = make(map[string]*templateCounter)
+	r.EnableProfiling()
+	addTemplate(t, r, QueryTemplate{Name: "open", Flag: "open", Description: "open PRs", Query: "is:pr is:open"})
+
+	if _, err := r.BuildQuery("open", "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.BuildQuery("open", "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := r.Stats()
+	s, ok := stats["open"]
+	if !ok {
+		t.Fatal("expected stats for \"open\"")
+	}
+	if s.Count != 2 {
+		t.Errorf("expected Count 2, got %d", s.Count)
+	}
+	if s.TotalResultSize != 2*int64(len("is:pr is:open")) {
+		t.Errorf("expected TotalResultSize %d, got %d", 2*int64(len("is:pr is:open")), s.TotalResultSize)
+	}
+}
+
+func TestBuildQuery_NoStatsWhenProfilingDisabled(t *testing.T) {
+	r := newTestRegistry()
+	r.stats = make(map[string]*templateCounter)
+	addTemplate(t, r, QueryTemplate{Name: "open", Flag: "open", Description: "open PRs", Query: "is:pr is:open"})
+
+	if _, err := r.BuildQuery("open", "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats := r.Stats(); len(stats) != 0 {
+		t.Errorf("expected no stats recorded, got %v", stats)
+	}
+}
+
+func TestPrintStats_SortsByDurationDescending(t *testing.T) {
+	r := newTestRegistry()
+	r.stats = map[string]*templateCounter{
+		"fast": {},
+		"slow": {},
+	}
+	r.stats["fast"].count.Store(1)
+	r.stats["fast"].duration.Store(10)
+	r.stats["slow"].count.Store(1)
+	r.stats["slow"].duration.Store(1000)
+	r.profiling = true
+
+	var buf bytes.Buffer
+	r.PrintStats(&buf)
+
+	out := buf.String()
+	if strings.Index(out, "slow") > strings.Index(out, "fast") {
+		t.Errorf("expected \"slow\" (higher cumulative time) to sort before \"fast\", got:\n%s", out)
+	}
+}
+
+func BenchmarkBuildQuery_Static(b *testing.B) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{Name: "open", Flag: "open", Description: "open PRs", Query: "is:pr is:open"}
+	if err := r.validateTemplate(tpl); err != nil {
+		b.Fatalf("unexpected validation error: %v", err)
+	}
+	r.templates[tpl.Flag] = *tpl
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := r.BuildQuery("open", "", nil); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkBuildQuery_Parameterized(b *testing.B) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{
+		Name: "author", Flag: "author", Description: "PRs by author",
+		QueryTemplate: "is:pr {{author .Value}}", Parameterized: true,
+	}
+	if err := r.validateTemplate(tpl); err != nil {
+		b.Fatalf("unexpected validation error: %v", err)
+	}
+	r.templates[tpl.Flag] = *tpl
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := r.BuildQuery("author", "octocat", nil); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
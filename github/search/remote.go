@@ -0,0 +1,198 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cli/go-gh"
+	"gopkg.in/yaml.v3"
+)
+
+// RegistryEntry names one remote template registry, configured by the
+// user in ~/.config/autoprat/registries.yaml. URL currently must point
+// at a directory in a GitHub repo (github.com/owner/repo[/path]); plain
+// HTTPS tarballs aren't supported yet.
+type RegistryEntry struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+}
+
+// registriesConfig is the top-level shape of registries.yaml.
+type registriesConfig struct {
+	Registries []RegistryEntry `yaml:"registries"`
+}
+
+// TemplateLoadOptions controls how remote template registries behave
+// when building a TemplateRegistry.
+type TemplateLoadOptions struct {
+	// Offline, when true, only reads registries from their local
+	// cache and never hits the network.
+	Offline bool
+
+	// Refresh, when true, re-fetches every configured registry even
+	// if its cache is already populated.
+	Refresh bool
+}
+
+// restContentClient abstracts the GitHub contents API call used to
+// list a registry's template directory, so it can be faked in tests
+// instead of hard-coding gh.RESTClient(nil).
+type restContentClient interface {
+	Get(path string, response interface{}) error
+}
+
+// newDefaultRESTClient wraps go-gh's default REST client so existing
+// `gh` authentication works for private registries.
+func newDefaultRESTClient() (restContentClient, error) {
+	return gh.RESTClient(nil)
+}
+
+// contentEntry matches one element of the GitHub contents API's
+// directory-listing response.
+type contentEntry struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Type        string `json:"type"`
+	DownloadURL string `json:"download_url"`
+}
+
+// registriesConfigPath returns ~/.config/autoprat/registries.yaml (or
+// $XDG_CONFIG_HOME's equivalent).
+func registriesConfigPath() string {
+	return filepath.Join(configHome(), "autoprat", "registries.yaml")
+}
+
+// registryCacheDir returns the on-disk cache directory for a named
+// registry: ~/.config/autoprat/cache/<name>/.
+func registryCacheDir(name string) string {
+	return filepath.Join(configHome(), "autoprat", "cache", name)
+}
+
+// loadRegistriesConfig reads registries.yaml, returning an empty,
+// non-error result if the file doesn't exist.
+func loadRegistriesConfig() ([]RegistryEntry, error) {
+	content, err := os.ReadFile(registriesConfigPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", registriesConfigPath(), err)
+	}
+
+	var cfg registriesConfig
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", registriesConfigPath(), err)
+	}
+	return cfg.Registries, nil
+}
+
+// parseGitHubRegistryURL splits a "github.com/owner/repo/path/to/dir"
+// (optionally prefixed with a scheme) into its owner, repo, and
+// in-repo path components.
+func parseGitHubRegistryURL(rawURL string) (owner, repo, path string, ok bool) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(rawURL, "https://"), "http://")
+	trimmed, ok = strings.CutPrefix(trimmed, "github.com/")
+	if !ok {
+		return "", "", "", false
+	}
+
+	parts := strings.SplitN(trimmed, "/", 3)
+	if len(parts) < 2 {
+		return "", "", "", false
+	}
+
+	owner = parts[0]
+	repo = parts[1]
+	if len(parts) == 3 {
+		path = parts[2]
+	}
+	return owner, repo, path, true
+}
+
+// fetchRegistry lists a registry's directory via the GitHub contents
+// API and downloads every ".yaml" entry, writing each to the
+// registry's cache directory.
+func fetchRegistry(client restContentClient, entry RegistryEntry) error {
+	owner, repo, path, ok := parseGitHubRegistryURL(entry.URL)
+	if !ok {
+		return fmt.Errorf("registry %q: unsupported URL %q, expected github.com/owner/repo[/path]", entry.Name, entry.URL)
+	}
+
+	apiPath := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, path)
+	var listing []contentEntry
+	if err := client.Get(apiPath, &listing); err != nil {
+		return fmt.Errorf("registry %q: failed to list %s: %w", entry.Name, entry.URL, err)
+	}
+
+	cacheDir := registryCacheDir(entry.Name)
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return fmt.Errorf("registry %q: failed to create cache directory: %w", entry.Name, err)
+	}
+
+	for _, item := range listing {
+		if item.Type != "file" || !strings.HasSuffix(item.Name, ".yaml") {
+			continue
+		}
+
+		resp, err := http.Get(item.DownloadURL)
+		if err != nil {
+			return fmt.Errorf("registry %q: failed to fetch %s: %w", entry.Name, item.Name, err)
+		}
+		content, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("registry %q: failed to read %s: %w", entry.Name, item.Name, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(cacheDir, item.Name), content, 0o644); err != nil {
+			return fmt.Errorf("registry %q: failed to cache %s: %w", entry.Name, item.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// loadRemoteTemplates loads every configured registry's templates,
+// tagged with Source "remote:<name>". Unless opts.Offline is set, a
+// registry is fetched before loading when it has no cache directory
+// yet or opts.Refresh is set; otherwise its existing cache is loaded
+// as is. Fetch failures fall back to whatever is already cached, with
+// a warning, rather than aborting.
+func (r *TemplateRegistry) loadRemoteTemplates(opts TemplateLoadOptions) error {
+	entries, err := loadRegistriesConfig()
+	if err != nil {
+		return err
+	}
+
+	var client restContentClient
+	if !opts.Offline {
+		client, err = newDefaultRESTClient()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to create GitHub client for remote registries: %v\n", err)
+		}
+	}
+
+	for _, entry := range entries {
+		source := "remote:" + entry.Name
+		cacheDir := registryCacheDir(entry.Name)
+
+		_, cacheErr := os.Stat(cacheDir)
+		needsFetch := !opts.Offline && client != nil && (opts.Refresh || os.IsNotExist(cacheErr))
+
+		if needsFetch {
+			if err := fetchRegistry(client, entry); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v; using cached templates if any\n", err)
+			}
+		}
+
+		if err := r.loadTemplatesDir(cacheDir, source); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load %s templates: %v\n", source, err)
+		}
+	}
+
+	return nil
+}
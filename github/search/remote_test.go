@@ -0,0 +1,183 @@
+package search
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGitHubRegistryURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		rawURL    string
+		wantOwner string
+		wantRepo  string
+		wantPath  string
+		wantOK    bool
+	}{
+		{
+			name:      "with scheme and path",
+			rawURL:    "https://github.com/frobware/autoprat-templates/registry",
+			wantOwner: "frobware",
+			wantRepo:  "autoprat-templates",
+			wantPath:  "registry",
+			wantOK:    true,
+		},
+		{
+			name:      "without scheme or path",
+			rawURL:    "github.com/frobware/autoprat-templates",
+			wantOwner: "frobware",
+			wantRepo:  "autoprat-templates",
+			wantPath:  "",
+			wantOK:    true,
+		},
+		{
+			name:   "unsupported host",
+			rawURL: "https://gitlab.com/frobware/autoprat-templates",
+			wantOK: false,
+		},
+		{
+			name:   "missing repo",
+			rawURL: "github.com/frobware",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, repo, path, ok := parseGitHubRegistryURL(tt.rawURL)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !tt.wantOK {
+				return
+			}
+			if owner != tt.wantOwner || repo != tt.wantRepo || path != tt.wantPath {
+				t.Errorf("got (%q, %q, %q), want (%q, %q, %q)", owner, repo, path, tt.wantOwner, tt.wantRepo, tt.wantPath)
+			}
+		})
+	}
+}
+
+// fakeRESTClient is a restContentClient backed by an in-memory listing,
+// so fetchRegistry can be tested without a network call.
+type fakeRESTClient struct {
+	listing []contentEntry
+	err     error
+}
+
+func (f *fakeRESTClient) Get(path string, response interface{}) error {
+	if f.err != nil {
+		return f.err
+	}
+	data, err := json.Marshal(f.listing)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, response)
+}
+
+func TestLoadRegistriesConfig_NoFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	entries, err := loadRegistriesConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("entries = %v, want none", entries)
+	}
+}
+
+func TestLoadRegistriesConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	content := []byte(`
+registries:
+  - name: acme
+    url: github.com/acme/autoprat-templates
+`)
+	cfgDir := filepath.Join(dir, "autoprat")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "registries.yaml"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := loadRegistriesConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "acme" || entries[0].URL != "github.com/acme/autoprat-templates" {
+		t.Errorf("entries = %+v, want one acme entry", entries)
+	}
+}
+
+func TestFetchRegistry(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	client := &fakeRESTClient{
+		listing: []contentEntry{
+			{Name: "open.yaml", Path: "registry/open.yaml", Type: "file", DownloadURL: "ignored"},
+			{Name: "README.md", Path: "registry/README.md", Type: "file", DownloadURL: "ignored"},
+		},
+	}
+
+	// fetchRegistry downloads each entry's DownloadURL with a plain
+	// http.Get, which a fake client can't intercept, so this test only
+	// exercises the listing/filtering side: a non-existent download
+	// host is expected to fail and be reported.
+	err := fetchRegistry(client, RegistryEntry{Name: "acme", URL: "github.com/acme/autoprat-templates"})
+	if err == nil {
+		t.Fatal("expected an error fetching from a placeholder download URL")
+	}
+}
+
+func TestLoadRemoteTemplates_Offline(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfgDir := filepath.Join(dir, "autoprat")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	registryCfg := []byte(`
+registries:
+  - name: acme
+    url: github.com/acme/autoprat-templates
+`)
+	if err := os.WriteFile(filepath.Join(cfgDir, "registries.yaml"), registryCfg, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cacheDir := filepath.Join(cfgDir, "cache", "acme")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tpl := []byte(`
+name: ACME Open
+flag: acme-open
+description: ACME's open PRs
+query: is:pr is:open
+`)
+	if err := os.WriteFile(filepath.Join(cacheDir, "open.yaml"), tpl, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newTestRegistry()
+	if err := r.loadRemoteTemplates(TemplateLoadOptions{Offline: true}); err != nil {
+		t.Fatalf("loadRemoteTemplates failed: %v", err)
+	}
+
+	got, ok := r.templates["acme-open"]
+	if !ok {
+		t.Fatal("expected acme-open template to be loaded from cache")
+	}
+	if got.Source != "remote:acme" {
+		t.Errorf("Source = %q, want %q", got.Source, "remote:acme")
+	}
+}
@@ -0,0 +1,288 @@
+package search
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed query-template.schema.json
+var templateSchema []byte
+
+// Schema returns the embedded JSON Schema describing the QueryTemplate
+// YAML format, for editors/LSPs to consume.
+func (r *TemplateRegistry) Schema() []byte {
+	return templateSchema
+}
+
+var flagPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var flagShortPattern = regexp.MustCompile(`^[a-zA-Z]$`)
+
+// SchemaViolation is a single schema check failure, located by line:col
+// in the offending YAML file.
+type SchemaViolation struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", v.Path, v.Line, v.Column, v.Message)
+}
+
+// SchemaViolations aggregates every violation found across one or more
+// files, rather than aborting at the first.
+type SchemaViolations []SchemaViolation
+
+func (vs SchemaViolations) Error() string {
+	lines := make([]string, len(vs))
+	for i, v := range vs {
+		lines[i] = v.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validateTemplateYAML checks content against the rules described by
+// query-template.schema.json — required fields, mutually exclusive
+// query/query_template, the flag/flag_short patterns — reporting every
+// violation found rather than stopping at the first, and locating each
+// one by line:col via the parsed yaml.Node.
+func validateTemplateYAML(path string, content []byte) SchemaViolations {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return SchemaViolations{{Path: path, Line: 1, Column: 1, Message: err.Error()}}
+	}
+	if len(doc.Content) == 0 {
+		return SchemaViolations{{Path: path, Line: 1, Column: 1, Message: "expected a YAML mapping"}}
+	}
+	return validateTemplateMappingNode(path, doc.Content[0])
+}
+
+// validateTemplateMappingNode is validateTemplateYAML's body, applied
+// directly to an already-parsed mapping node. This lets
+// parseTemplateDocuments validate each entry of a "templates:" bundle
+// the same way, without re-marshalling it back to YAML text first.
+func validateTemplateMappingNode(path string, mapping *yaml.Node) SchemaViolations {
+	if mapping.Kind != yaml.MappingNode {
+		return SchemaViolations{{Path: path, Line: mapping.Line, Column: mapping.Column, Message: "expected a YAML mapping"}}
+	}
+
+	fields := make(map[string]*yaml.Node, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		fields[mapping.Content[i].Value] = mapping.Content[i+1]
+	}
+
+	var violations SchemaViolations
+	violation := func(node *yaml.Node, format string, args ...any) {
+		line, col := mapping.Line, mapping.Column
+		if node != nil {
+			line, col = node.Line, node.Column
+		}
+		violations = append(violations, SchemaViolation{
+			Path: path, Line: line, Column: col,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, required := range []string{"name", "flag", "description"} {
+		if fields[required] == nil {
+			violation(nil, "missing required field %q", required)
+		}
+	}
+
+	if flag, ok := fields["flag"]; ok && flag.Value != "" && !flagPattern.MatchString(flag.Value) {
+		violation(flag, "flag %q must be kebab-case ASCII (match %s)", flag.Value, flagPattern.String())
+	}
+	if flagShort, ok := fields["flag_short"]; ok && !flagShortPattern.MatchString(flagShort.Value) {
+		violation(flagShort, "flag_short must be a single ASCII letter (match %s), got %q", flagShortPattern.String(), flagShort.Value)
+	}
+
+	query, hasQuery := fields["query"]
+	_, hasQueryTemplate := fields["query_template"]
+	switch {
+	case !hasQuery && !hasQueryTemplate:
+		violation(nil, "either query or query_template is required")
+	case hasQuery && hasQueryTemplate:
+		violation(query, "only one of query or query_template may be specified")
+	}
+
+	return violations
+}
+
+// findMappingField returns the value node for key in mapping, or nil if
+// mapping isn't a mapping node or doesn't contain key.
+func findMappingField(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// parseTemplateDocuments decodes content as one or more YAML documents
+// (separated by "---"), so a single file can define many templates.
+// Each document is either a single QueryTemplate mapping, or a
+// top-level "templates:" list of them. Every entry is validated and
+// decoded independently, located for error messages as "loc" for the
+// first document, "loc:docIndex" for later ones, and that document
+// location plus ":entryIndex" for bundle entries ("loc:entryIndex" in
+// the first document, "loc:docIndex:entryIndex" later) — so one bad entry
+// doesn't prevent the rest of the file from loading.
+func parseTemplateDocuments(loc string, content []byte) ([]QueryTemplate, SchemaViolations) {
+	var templates []QueryTemplate
+	var violations SchemaViolations
+
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	for docIndex := 0; ; docIndex++ {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			violations = append(violations, SchemaViolation{Path: loc, Line: 1, Column: 1, Message: err.Error()})
+			break
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+
+		docLoc := loc
+		if docIndex > 0 {
+			docLoc = fmt.Sprintf("%s:%d", loc, docIndex)
+		}
+
+		root := doc.Content[0]
+		if bundle := findMappingField(root, "templates"); bundle != nil {
+			for entryIndex, entry := range bundle.Content {
+				entryLoc := fmt.Sprintf("%s:%d", docLoc, entryIndex)
+				if fv := validateTemplateMappingNode(entryLoc, entry); len(fv) > 0 {
+					violations = append(violations, fv...)
+					continue
+				}
+				var tpl QueryTemplate
+				if err := entry.Decode(&tpl); err != nil {
+					violations = append(violations, SchemaViolation{Path: entryLoc, Line: entry.Line, Column: entry.Column, Message: err.Error()})
+					continue
+				}
+				templates = append(templates, tpl)
+			}
+			continue
+		}
+
+		if fv := validateTemplateMappingNode(docLoc, root); len(fv) > 0 {
+			violations = append(violations, fv...)
+			continue
+		}
+		var tpl QueryTemplate
+		if err := root.Decode(&tpl); err != nil {
+			violations = append(violations, SchemaViolation{Path: docLoc, Line: root.Line, Column: root.Column, Message: err.Error()})
+			continue
+		}
+		templates = append(templates, tpl)
+	}
+
+	return templates, violations
+}
+
+// ValidationIssue is a single problem found by ValidateTemplateFile,
+// located by line:col in the offending YAML file where available.
+type ValidationIssue struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (i ValidationIssue) String() string {
+	if i.Line == 0 && i.Column == 0 {
+		return fmt.Sprintf("%s: %s", i.Path, i.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", i.Path, i.Line, i.Column, i.Message)
+}
+
+func issuesFromViolations(vs SchemaViolations) []ValidationIssue {
+	issues := make([]ValidationIssue, len(vs))
+	for i, v := range vs {
+		issues[i] = ValidationIssue{Path: v.Path, Line: v.Line, Column: v.Column, Message: v.Message}
+	}
+	return issues
+}
+
+// ValidateTemplateFile runs every check available on a standalone
+// template file at path: the schema checks above, strict unknown-field
+// detection, (*TemplateRegistry).validateTemplate (which also covers
+// placeholder/parameterized consistency), a flag-name collision check
+// against the embedded templates, and a dry-run BuildQuery with
+// representative sample inputs. It's the engine behind `autoprat
+// templates validate`. All schema and unknown-field problems are
+// reported together; if there are any, the later checks are skipped,
+// and a validateTemplate failure likewise skips the dry run.
+func ValidateTemplateFile(path string) []ValidationIssue {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return []ValidationIssue{{Path: path, Message: fmt.Sprintf("failed to read file: %v", err)}}
+	}
+
+	var issues []ValidationIssue
+
+	issues = append(issues, issuesFromViolations(validateTemplateYAML(path, content))...)
+
+	var strict QueryTemplate
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(&strict); err != nil && strings.Contains(err.Error(), "field") {
+		issues = append(issues, ValidationIssue{Path: path, Message: err.Error()})
+	}
+
+	if len(issues) > 0 {
+		return issues
+	}
+
+	var tpl QueryTemplate
+	if err := yaml.Unmarshal(content, &tpl); err != nil {
+		return []ValidationIssue{{Path: path, Message: err.Error()}}
+	}
+
+	r, err := embeddedOnlyTemplateRegistry()
+	if err != nil {
+		return []ValidationIssue{{Path: path, Message: fmt.Sprintf("failed to load embedded templates: %v", err)}}
+	}
+
+	if _, exists := r.templates[tpl.Flag]; exists {
+		issues = append(issues, ValidationIssue{Path: path, Message: fmt.Sprintf("flag %q collides with an embedded template", tpl.Flag)})
+	}
+
+	if err := r.validateTemplate(&tpl); err != nil {
+		issues = append(issues, ValidationIssue{Path: path, Message: err.Error()})
+		return issues
+	}
+
+	r.templates[tpl.Flag] = tpl
+	if _, err := r.BuildQuery(tpl.Flag, "sample-value", []string{"sample-a", "sample-b"}); err != nil {
+		issues = append(issues, ValidationIssue{Path: path, Message: fmt.Sprintf("dry-run BuildQuery failed: %v", err)})
+	}
+
+	return issues
+}
+
+// embeddedOnlyTemplateRegistry loads just the embedded templates, for
+// use as a baseline when validating a standalone template file.
+func embeddedOnlyTemplateRegistry() (*TemplateRegistry, error) {
+	r := &TemplateRegistry{templates: make(map[string]QueryTemplate)}
+	if err := r.loadEmbeddedTemplates(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,215 @@
+package search
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateTemplateYAML_MissingFields(t *testing.T) {
+	violations := validateTemplateYAML("bad.yaml", []byte("flag: ok-flag\n"))
+	if len(violations) < 2 {
+		t.Fatalf("expected at least 2 violations (missing name/description, missing query), got %d: %v", len(violations), violations)
+	}
+}
+
+func TestValidateTemplateYAML_RejectsBadFlag(t *testing.T) {
+	content := []byte("name: Bad\nflag: Not_Kebab\ndescription: d\nquery: is:pr\n")
+	violations := validateTemplateYAML("bad.yaml", content)
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+	if !strings.Contains(violations[0].Message, "kebab-case") {
+		t.Errorf("expected kebab-case violation, got %q", violations[0].Message)
+	}
+}
+
+func TestValidateTemplateYAML_RejectsBothQueryFields(t *testing.T) {
+	content := []byte("name: n\nflag: ok\ndescription: d\nquery: is:pr\nquery_template: is:pr {{.Value}}\n")
+	violations := validateTemplateYAML("bad.yaml", content)
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+}
+
+func TestValidateTemplateYAML_Valid(t *testing.T) {
+	content := []byte("name: Open\nflag: open\ndescription: open PRs\nquery: is:pr is:open\n")
+	if violations := validateTemplateYAML("ok.yaml", content); len(violations) != 0 {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+}
+
+func TestSchemaViolation_String(t *testing.T) {
+	v := SchemaViolation{Path: "x.yaml", Line: 3, Column: 5, Message: "boom"}
+	if got, want := v.String(), "x.yaml:3:5: boom"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestValidateTemplateFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tpl.yaml")
+	if err := os.WriteFile(path, []byte("name: Open\nflag: my-open\ndescription: open PRs\nquery: is:pr is:open\n"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	if issues := ValidateTemplateFile(path); len(issues) != 0 {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+}
+
+func TestValidateTemplateFile_RejectsSchemaViolations(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tpl.yaml")
+	if err := os.WriteFile(path, []byte("flag: Not_Kebab\n"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	issues := ValidateTemplateFile(path)
+	if len(issues) == 0 {
+		t.Fatal("expected at least one issue")
+	}
+}
+
+func TestValidateTemplateFile_RejectsUnknownField(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tpl.yaml")
+	content := "name: Open\nflag: my-open\ndescription: open PRs\nquery: is:pr is:open\nbogus_field: oops\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	issues := ValidateTemplateFile(path)
+	if len(issues) == 0 {
+		t.Fatal("expected an unknown-field issue")
+	}
+	if !strings.Contains(issues[0].Message, "bogus_field") {
+		t.Errorf("expected issue to mention bogus_field, got %v", issues)
+	}
+}
+
+func TestValidateTemplateFile_RejectsFlagCollisionWithEmbedded(t *testing.T) {
+	base, err := embeddedOnlyTemplateRegistry()
+	if err != nil {
+		t.Fatalf("failed to load embedded templates: %v", err)
+	}
+	var existingFlag string
+	for flag := range base.templates {
+		existingFlag = flag
+		break
+	}
+	if existingFlag == "" {
+		t.Skip("no embedded templates to collide with")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tpl.yaml")
+	content := fmt.Sprintf("name: Dup\nflag: %s\ndescription: duplicate\nquery: is:pr is:open\n", existingFlag)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	issues := ValidateTemplateFile(path)
+	found := false
+	for _, issue := range issues {
+		if strings.Contains(issue.Message, "collides with an embedded template") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a flag-collision issue, got %v", issues)
+	}
+}
+
+func TestParseTemplateDocuments_Single(t *testing.T) {
+	content := []byte("name: Open\nflag: open\ndescription: open PRs\nquery: is:pr is:open\n")
+	templates, violations := parseTemplateDocuments("tpl.yaml", content)
+	if len(violations) != 0 {
+		t.Fatalf("unexpected violations: %v", violations)
+	}
+	if len(templates) != 1 || templates[0].Flag != "open" {
+		t.Fatalf("templates = %+v, want one with flag open", templates)
+	}
+}
+
+func TestParseTemplateDocuments_MultiDocument(t *testing.T) {
+	content := []byte(`
+name: Open
+flag: open
+description: open PRs
+query: is:pr is:open
+---
+name: Closed
+flag: closed
+description: closed PRs
+query: is:pr is:closed
+`)
+	templates, violations := parseTemplateDocuments("queries.yaml", content)
+	if len(violations) != 0 {
+		t.Fatalf("unexpected violations: %v", violations)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 templates, got %d: %+v", len(templates), templates)
+	}
+	if templates[0].Flag != "open" || templates[1].Flag != "closed" {
+		t.Errorf("got flags %q, %q, want open, closed", templates[0].Flag, templates[1].Flag)
+	}
+}
+
+func TestParseTemplateDocuments_TemplatesListForm(t *testing.T) {
+	content := []byte(`
+templates:
+  - name: Open
+    flag: open
+    description: open PRs
+    query: is:pr is:open
+  - name: Closed
+    flag: closed
+    description: closed PRs
+    query: is:pr is:closed
+`)
+	templates, violations := parseTemplateDocuments("queries.yaml", content)
+	if len(violations) != 0 {
+		t.Fatalf("unexpected violations: %v", violations)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 templates, got %d: %+v", len(templates), templates)
+	}
+}
+
+func TestParseTemplateDocuments_OneBadEntryDoesNotSinkTheRest(t *testing.T) {
+	content := []byte(`
+templates:
+  - name: Open
+    flag: open
+    description: open PRs
+    query: is:pr is:open
+  - name: Bad
+    flag: Not_Kebab
+    description: bad entry
+    query: is:pr
+  - name: Closed
+    flag: closed
+    description: closed PRs
+    query: is:pr is:closed
+`)
+	templates, violations := parseTemplateDocuments("queries.yaml", content)
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %v", len(violations), violations)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected the 2 good templates to still load, got %d: %+v", len(templates), templates)
+	}
+	if !strings.Contains(violations[0].Path, "queries.yaml:1") {
+		t.Errorf("expected violation located at queries.yaml:1, got %q", violations[0].Path)
+	}
+}
+
+func TestTemplateRegistry_Schema(t *testing.T) {
+	r := &TemplateRegistry{templates: make(map[string]QueryTemplate)}
+	if len(r.Schema()) == 0 {
+		t.Error("expected non-empty schema")
+	}
+}
@@ -6,7 +6,11 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -24,32 +28,262 @@ type QueryTemplate struct {
 	QueryTemplate    string `yaml:"query_template,omitempty"`
 	Parameterized    bool   `yaml:"parameterized,omitempty"`
 	SupportsMultiple bool   `yaml:"supports_multiple,omitempty"`
-	Source           string `yaml:"-"` // "embedded" or "user"
+
+	// Funcs allow-lists the query-DSL funcs (beyond the always-available
+	// ones) that QueryTemplate may call. Only embedded templates may set
+	// this; it lets system templates reach privileged funcs that user
+	// templates can't.
+	Funcs []string `yaml:"funcs,omitempty"`
+
+	// Extends names a base template whose query this one inherits; the
+	// composed query is the base's query AND this one's. Include names
+	// partial fragments merged into the composed query alongside it. Both
+	// are resolved by flag/name across all sources, including partials.
+	Extends string   `yaml:"extends,omitempty"`
+	Include []string `yaml:"include,omitempty"`
+
+	// Source is "embedded", "system", "user", "repo", "partial", or
+	// "remote:<name>" for a template from a remote registry.
+	// Partials are loaded from the partials root, are resolvable by name
+	// from Extends/Include, but are never exposed as CLI flags. The
+	// four fixed non-partial sources correspond to the roots returned by
+	// Roots(), in ascending precedence order.
+	Source string `yaml:"-"`
+
+	// SourcePath is the full origin of this template: the embedded FS
+	// path for Source "embedded", or the on-disk YAML file path
+	// otherwise. See Origin.
+	SourcePath string `yaml:"-"`
+
+	// tmpl is the compiled form of QueryTemplate, populated by
+	// validateTemplate at load time. Nil for non-parameterized
+	// templates, which use Query verbatim.
+	tmpl *template.Template
+}
+
+// templateContext is the value QueryTemplate's compiled text/template is
+// executed against.
+type templateContext struct {
+	Value  string
+	Values []string
+	Repo   string
+}
+
+// queryDSLFuncs is the funcmap available to every query template,
+// tailored to the GitHub search DSL.
+var queryDSLFuncs = template.FuncMap{
+	"label":    func(name string) string { return "label:" + name },
+	"notLabel": func(name string) string { return "-label:" + name },
+	"author":   func(login string) string { return "author:" + login },
+	"notAuthor": func(login string) string {
+		return "-author:" + login
+	},
+	"org":     func(name string) string { return "org:" + name },
+	"repo":    func(name string) string { return "repo:" + name },
+	"daysAgo": func(n int) string { return fmt.Sprintf(">=%dd", n) },
+	"quote":   strconv.Quote,
+	"labels":  labelsTerm,
+}
+
+// privilegedQueryDSLFuncs are only reachable via a template's Funcs
+// allow-list, which only embedded templates may set (see
+// validateTemplate). env lets system templates pull in operator-chosen
+// values (e.g. an org name) without hard-coding them.
+var privilegedQueryDSLFuncs = template.FuncMap{
+	"env": os.Getenv,
+}
+
+// funcsFor builds the funcmap a template may call: the always-available
+// queryDSLFuncs, plus whichever privilegedQueryDSLFuncs tpl.Funcs names.
+func funcsFor(allow []string) template.FuncMap {
+	if len(allow) == 0 {
+		return queryDSLFuncs
+	}
+
+	fns := make(template.FuncMap, len(queryDSLFuncs)+len(allow))
+	for name, fn := range queryDSLFuncs {
+		fns[name] = fn
+	}
+	for _, name := range allow {
+		if fn, ok := privilegedQueryDSLFuncs[name]; ok {
+			fns[name] = fn
+		}
+	}
+	return fns
 }
 
-// TemplateRegistry holds all available query templates.
+// labelsTerm renders a []string of label names into space-separated
+// label:/-label: search terms, honoring the leading "-" negation
+// convention used by the CLI's --label/--without-label flags.
+func labelsTerm(names []string) string {
+	terms := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.HasPrefix(name, "-") {
+			terms = append(terms, "-label:"+strings.TrimPrefix(name, "-"))
+		} else {
+			terms = append(terms, "label:"+name)
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// systemTemplatesDir is the system-wide template root, below the user's
+// own config but above the embedded defaults.
+const systemTemplatesDir = "/etc/autoprat/templates"
+
+// TemplateRegistry holds all available query templates, loaded from a
+// precedence list of roots (see Roots) plus a separate partials root.
 type TemplateRegistry struct {
 	templates map[string]QueryTemplate
+
+	// roots holds the resolved root for each of the four non-partial
+	// sources, in ascending precedence order, indexed in lockstep with
+	// the "embedded"/"system"/"user"/"repo" Source values.
+	roots []string
+
+	// profiling, statsMu, and stats back BuildQuery's execution-stats
+	// recording; see EnableProfiling, recordQuery, and Stats.
+	profiling bool
+	statsMu   sync.Mutex
+	stats     map[string]*templateCounter
 }
 
-// NewTemplateRegistry creates a new template registry and loads all templates.
+// NewTemplateRegistry creates a new template registry and loads all
+// templates from every root in Roots(), in precedence order, so later
+// roots override earlier ones by flag name. Remote registries (see
+// TemplateLoadOptions) are fetched fresh if their cache is empty.
 func NewTemplateRegistry() (*TemplateRegistry, error) {
+	return NewTemplateRegistryWithMode(TemplateLoadOptions{})
+}
+
+// NewTemplateRegistryWithMode creates a new template registry like
+// NewTemplateRegistry, but lets the caller control remote-registry
+// behaviour via opts (offline mode, forced refresh), e.g. for
+// --refresh-templates.
+func NewTemplateRegistryWithMode(opts TemplateLoadOptions) (*TemplateRegistry, error) {
 	r := &TemplateRegistry{
 		templates: make(map[string]QueryTemplate),
+		roots:     []string{"embedded", systemTemplatesDir, userTemplatesDir(), repoTemplatesDir()},
+		stats:     make(map[string]*templateCounter),
+		profiling: profilingFromEnv(),
 	}
 
 	if err := r.loadEmbeddedTemplates(); err != nil {
 		return nil, fmt.Errorf("failed to load embedded templates: %w", err)
 	}
 
-	if err := r.loadUserTemplates(); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to load user templates: %v\n", err)
+	if err := r.loadTemplatesDir(systemTemplatesDir, "system"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load system templates: %v\n", err)
+	}
+
+	// Remote registries sit between system and user in precedence, so
+	// a user template always wins over a remote one of the same flag,
+	// per Roots' doc comment.
+	if err := r.loadRemoteTemplates(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load remote registries: %v\n", err)
+	}
+
+	for _, source := range []string{"user", "repo"} {
+		dir := userTemplatesDir()
+		if source == "repo" {
+			dir = repoTemplatesDir()
+		}
+		if err := r.loadTemplatesDir(dir, source); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load %s templates: %v\n", source, err)
+		}
+	}
+
+	if err := r.loadPartialTemplates(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load partial templates: %v\n", err)
 	}
 
 	return r, nil
 }
 
+// Roots returns the template roots in ascending precedence order:
+// embedded, system (/etc/autoprat/templates), user
+// ($XDG_CONFIG_HOME or $HOME/.config/autoprat/templates), then
+// repo-local ($PWD/.autoprat/templates). Templates from later roots
+// override earlier ones by flag name.
+//
+// Remote registries configured in registries.yaml (Source
+// "remote:<name>") aren't included here since there can be any number
+// of them; they load between "system" and "user" in precedence, so a
+// user template always wins over a remote one of the same flag, which
+// in turn wins over an embedded one.
+func (r *TemplateRegistry) Roots() []string {
+	return r.roots
+}
+
+// Origin returns the root and full on-disk (or embedded) path that flag
+// was loaded from.
+func (r *TemplateRegistry) Origin(flag string) (root, path string) {
+	tpl, exists := r.templates[flag]
+	if !exists {
+		return "", ""
+	}
+	return tpl.Source, tpl.SourcePath
+}
+
+// userTemplatesDir resolves the user template root: $XDG_CONFIG_HOME, or
+// $HOME/.config if unset, joined with autoprat/templates.
+func userTemplatesDir() string {
+	return filepath.Join(configHome(), "autoprat", "templates")
+}
+
+// repoTemplatesDir resolves the repo-local template root by walking
+// upward from the current working directory for a ".autoprat.yaml"
+// file or ".autoprat" directory, so teams can check query conventions
+// into the repo itself and run autoprat from any subdirectory of the
+// checkout, the way .git is discovered.
+func repoTemplatesDir() string {
+	dir, ok := findProjectDir()
+	if !ok {
+		return ""
+	}
+	return filepath.Join(dir, ".autoprat", "templates")
+}
+
+// findProjectDir walks upward from $PWD looking for a ".autoprat.yaml"
+// file or ".autoprat" directory, returning the first directory that
+// has either.
+func findProjectDir() (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".autoprat.yaml")); err == nil {
+			return dir, true
+		}
+		if info, err := os.Stat(filepath.Join(dir, ".autoprat")); err == nil && info.IsDir() {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// configHome resolves $XDG_CONFIG_HOME, falling back to $HOME/.config.
+func configHome() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".config")
+}
+
 // loadEmbeddedTemplates loads templates from the embedded filesystem.
+// Each file may hold one template, several "---"-separated documents,
+// or a top-level "templates:" list — see parseTemplateDocuments.
 func (r *TemplateRegistry) loadEmbeddedTemplates() error {
 	entries, err := embeddedTemplates.ReadDir("templates/embedded")
 	if err != nil {
@@ -61,43 +295,106 @@ func (r *TemplateRegistry) loadEmbeddedTemplates() error {
 			continue
 		}
 
-		content, err := embeddedTemplates.ReadFile("templates/embedded/" + entry.Name())
+		embeddedPath := "templates/embedded/" + entry.Name()
+		content, err := embeddedTemplates.ReadFile(embeddedPath)
 		if err != nil {
 			return fmt.Errorf("failed to read embedded template file %s: %w", entry.Name(), err)
 		}
 
-		var template QueryTemplate
-		if err := yaml.Unmarshal(content, &template); err != nil {
-			return fmt.Errorf("failed to parse embedded template file %s: %w", entry.Name(), err)
+		templates, violations := parseTemplateDocuments(embeddedPath, content)
+		if len(violations) > 0 {
+			return fmt.Errorf("invalid embedded template file %s: %w", entry.Name(), violations)
 		}
 
-		if err := r.validateTemplate(template); err != nil {
-			return fmt.Errorf("invalid embedded template %s: %w", entry.Name(), err)
-		}
+		for _, tpl := range templates {
+			tpl.Source = "embedded"
+			tpl.SourcePath = embeddedPath
+			if err := r.validateTemplate(&tpl); err != nil {
+				return fmt.Errorf("invalid embedded template %s: %w", entry.Name(), err)
+			}
 
-		template.Source = "embedded"
-		r.templates[template.Flag] = template
+			r.templates[tpl.Flag] = tpl
+		}
 	}
 
 	return nil
 }
 
-// loadUserTemplates loads templates from the user's config directory.
-func (r *TemplateRegistry) loadUserTemplates() error {
-	homeDir, err := os.UserHomeDir()
+// loadTemplatesDir loads templates from dir, tagging each with source,
+// so later calls (with higher-precedence sources) override earlier ones
+// by flag name. A missing dir is not an error. Each file may hold one
+// template, several "---"-separated documents, or a top-level
+// "templates:" list (see parseTemplateDocuments); violations from every
+// file (and every entry within it) are aggregated into a single
+// SchemaViolations error rather than aborting at the first bad one, so
+// a typo in one template doesn't hide problems in another.
+func (r *TemplateRegistry) loadTemplatesDir(dir, source string) error {
+	if dir == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return fmt.Errorf("failed to get user home directory: %w", err)
+		return fmt.Errorf("failed to read %s templates directory: %w", source, err)
 	}
 
-	templatesDir := filepath.Join(homeDir, ".config", "autoprat", "templates")
+	var violations SchemaViolations
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s template file %s: %w", source, entry.Name(), err)
+		}
+
+		templates, fileViolations := parseTemplateDocuments(path, content)
+		if len(fileViolations) > 0 {
+			violations = append(violations, fileViolations...)
+			continue
+		}
 
-	if _, err := os.Stat(templatesDir); os.IsNotExist(err) {
+		for _, tpl := range templates {
+			tpl.Source = source
+			tpl.SourcePath = path
+			if err := r.validateTemplate(&tpl); err != nil {
+				return fmt.Errorf("invalid %s template %s: %w", source, entry.Name(), err)
+			}
+
+			if existing, exists := r.templates[tpl.Flag]; exists && existing.Source != source {
+				fmt.Fprintf(os.Stderr, "Warning: %s template %q overrides %s template of the same flag (%s)\n", source, tpl.Flag, existing.Source, tpl.Flag)
+			}
+
+			r.templates[tpl.Flag] = tpl
+		}
+	}
+
+	if len(violations) > 0 {
+		return violations
+	}
+
+	return nil
+}
+
+// loadPartialTemplates loads partial query fragments from the user's
+// config directory. Partials are resolvable by name from Extends/Include
+// but, unlike embedded/user templates, never become CLI flags.
+func (r *TemplateRegistry) loadPartialTemplates() error {
+	partialsDir := filepath.Join(configHome(), "autoprat", "partials")
+
+	if _, err := os.Stat(partialsDir); os.IsNotExist(err) {
 		return nil
 	}
 
-	entries, err := os.ReadDir(templatesDir)
+	entries, err := os.ReadDir(partialsDir)
 	if err != nil {
-		return fmt.Errorf("failed to read user templates directory: %w", err)
+		return fmt.Errorf("failed to read partials directory: %w", err)
 	}
 
 	for _, entry := range entries {
@@ -105,47 +402,69 @@ func (r *TemplateRegistry) loadUserTemplates() error {
 			continue
 		}
 
-		content, err := os.ReadFile(filepath.Join(templatesDir, entry.Name()))
+		path := filepath.Join(partialsDir, entry.Name())
+		content, err := os.ReadFile(path)
 		if err != nil {
-			return fmt.Errorf("failed to read user template file %s: %w", entry.Name(), err)
+			return fmt.Errorf("failed to read partial file %s: %w", entry.Name(), err)
 		}
 
-		var template QueryTemplate
-		if err := yaml.Unmarshal(content, &template); err != nil {
-			return fmt.Errorf("failed to parse user template file %s: %w", entry.Name(), err)
+		var tpl QueryTemplate
+		if err := yaml.Unmarshal(content, &tpl); err != nil {
+			return fmt.Errorf("failed to parse partial file %s: %w", entry.Name(), err)
 		}
 
-		if err := r.validateTemplate(template); err != nil {
-			return fmt.Errorf("invalid user template %s: %w", entry.Name(), err)
+		tpl.Source = "partial"
+		tpl.SourcePath = path
+		if err := r.validateTemplate(&tpl); err != nil {
+			return fmt.Errorf("invalid partial %s: %w", entry.Name(), err)
 		}
 
-		template.Source = "user"
-		r.templates[template.Flag] = template
+		r.templates[tpl.Flag] = tpl
 	}
 
 	return nil
 }
 
-// validateTemplate ensures a template definition is valid.
-func (r *TemplateRegistry) validateTemplate(template QueryTemplate) error {
-	if template.Name == "" {
+// validateTemplate ensures a template definition is valid, and compiles
+// QueryTemplate as a text/template so BuildQuery is just tmpl.Execute.
+// Only embedded templates may set Funcs, since it grants access to
+// privileged query-DSL funcs.
+func (r *TemplateRegistry) validateTemplate(tpl *QueryTemplate) error {
+	if tpl.Name == "" {
 		return fmt.Errorf("template name is required")
 	}
-	if template.Flag == "" {
+	if tpl.Flag == "" {
 		return fmt.Errorf("template flag is required")
 	}
-	if template.Description == "" {
+	if tpl.Description == "" && tpl.Source != "partial" {
 		return fmt.Errorf("template description is required")
 	}
-	if template.Query == "" && template.QueryTemplate == "" {
+	if tpl.Query == "" && tpl.QueryTemplate == "" {
 		return fmt.Errorf("either query or query_template is required")
 	}
-	if template.Query != "" && template.QueryTemplate != "" {
+	if tpl.Query != "" && tpl.QueryTemplate != "" {
 		return fmt.Errorf("only one of query or query_template should be specified")
 	}
-	if template.Parameterized && template.QueryTemplate == "" {
+	if tpl.Parameterized && tpl.QueryTemplate == "" {
 		return fmt.Errorf("parameterized templates must have query_template")
 	}
+	if !tpl.Parameterized && strings.Contains(tpl.QueryTemplate, ".Value") {
+		return fmt.Errorf("template %s references .Value but is not parameterized", tpl.Flag)
+	}
+	if !tpl.SupportsMultiple && strings.Contains(tpl.QueryTemplate, "range .Values") {
+		return fmt.Errorf("template %s ranges over .Values but does not support_multiple", tpl.Flag)
+	}
+	if tpl.Source != "embedded" && len(tpl.Funcs) > 0 {
+		return fmt.Errorf("template %s: funcs is only permitted for embedded templates", tpl.Flag)
+	}
+
+	if tpl.QueryTemplate != "" {
+		compiled, err := template.New(tpl.Flag).Funcs(funcsFor(tpl.Funcs)).Parse(tpl.QueryTemplate)
+		if err != nil {
+			return fmt.Errorf("template %s: %w", tpl.Flag, err)
+		}
+		tpl.tmpl = compiled
+	}
 
 	return nil
 }
@@ -161,10 +480,14 @@ func (r *TemplateRegistry) GetAllTemplates() map[string]QueryTemplate {
 	return r.templates
 }
 
-// GetFlags returns all available template flag names in sorted order.
+// GetFlags returns all available template flag names in sorted order,
+// excluding partials, which are never exposed as CLI flags.
 func (r *TemplateRegistry) GetFlags() []string {
 	var flags []string
-	for flag := range r.templates {
+	for flag, tpl := range r.templates {
+		if tpl.Source == "partial" {
+			continue
+		}
 		flags = append(flags, flag)
 	}
 
@@ -185,43 +508,89 @@ func (r *TemplateRegistry) GetFlagsBySource(source string) []string {
 	return flags
 }
 
-// BuildQuery builds a search query from a template with the given parameters.
-func (r *TemplateRegistry) BuildQuery(flag string, value string, values []string) (string, error) {
-	template, exists := r.GetTemplate(flag)
+// Resolve returns the flattened dependency chain for flag: every
+// template reachable by following Extends/Include, in dependency order
+// (ancestors and includes before the template that references them),
+// ending with flag's own template last. It detects cycles and missing
+// references across all sources, including partials.
+func (r *TemplateRegistry) Resolve(flag string) ([]QueryTemplate, error) {
+	var chain []QueryTemplate
+	if err := r.resolve(flag, make(map[string]bool), &chain); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
+
+func (r *TemplateRegistry) resolve(flag string, visiting map[string]bool, chain *[]QueryTemplate) error {
+	if visiting[flag] {
+		return fmt.Errorf("template cycle detected at %q", flag)
+	}
+	visiting[flag] = true
+	defer delete(visiting, flag)
+
+	tpl, exists := r.templates[flag]
 	if !exists {
-		return "", fmt.Errorf("template %s not found", flag)
+		return fmt.Errorf("template %q not found", flag)
+	}
+
+	if tpl.Extends != "" {
+		if err := r.resolve(tpl.Extends, visiting, chain); err != nil {
+			return err
+		}
+	}
+	for _, include := range tpl.Include {
+		if err := r.resolve(include, visiting, chain); err != nil {
+			return err
+		}
 	}
 
-	// Non-parameterized templates
-	if !template.Parameterized {
-		return template.Query, nil
+	*chain = append(*chain, tpl)
+	return nil
+}
+
+// renderQuery executes a single template's own Query/QueryTemplate
+// (ignoring Extends/Include) against ctx.
+func renderQuery(tpl QueryTemplate, ctx templateContext) (string, error) {
+	if !tpl.Parameterized {
+		return tpl.Query, nil
 	}
 
-	// Parameterized templates
-	if template.QueryTemplate == "" {
-		return "", fmt.Errorf("parameterized template %s missing query_template", flag)
+	if tpl.tmpl == nil {
+		return "", fmt.Errorf("parameterized template %s missing query_template", tpl.Flag)
 	}
 
-	query := template.QueryTemplate
+	var buf strings.Builder
+	if err := tpl.tmpl.Execute(&buf, ctx); err != nil {
+		return "", fmt.Errorf("template %s: %w", tpl.Flag, err)
+	}
+	return buf.String(), nil
+}
 
-	// Handle single value substitution
-	if strings.Contains(query, "{value}") {
-		query = strings.ReplaceAll(query, "{value}", value)
+// BuildQuery builds a search query from a template with the given
+// parameters, composing it from the full Extends/Include dependency
+// chain (see Resolve) by ANDing each link's own query together.
+func (r *TemplateRegistry) BuildQuery(flag string, value string, values []string) (string, error) {
+	start := time.Now()
+
+	chain, err := r.Resolve(flag)
+	if err != nil {
+		return "", err
 	}
 
-	// Handle multi-value substitution (for labels)
-	if strings.Contains(query, "{labels}") {
-		var labelTerms []string
-		for _, label := range values {
-			if strings.HasPrefix(label, "-") {
-				labelName := strings.TrimPrefix(label, "-")
-				labelTerms = append(labelTerms, fmt.Sprintf("-label:%s", labelName))
-			} else {
-				labelTerms = append(labelTerms, fmt.Sprintf("label:%s", label))
-			}
+	ctx := templateContext{Value: value, Values: values}
+
+	parts := make([]string, 0, len(chain))
+	for _, tpl := range chain {
+		part, err := renderQuery(tpl, ctx)
+		if err != nil {
+			return "", err
+		}
+		if part != "" {
+			parts = append(parts, part)
 		}
-		query = strings.ReplaceAll(query, "{labels}", strings.Join(labelTerms, " "))
 	}
 
+	query := strings.Join(parts, " AND ")
+	r.recordQuery(flag, time.Since(start), len(query))
 	return query, nil
 }
@@ -0,0 +1,201 @@
+package search
+
+import "testing"
+
+func newTestRegistry() *TemplateRegistry {
+	return &TemplateRegistry{templates: make(map[string]QueryTemplate)}
+}
+
+func mustValidate(t *testing.T, r *TemplateRegistry, tpl *QueryTemplate) {
+	t.Helper()
+	if err := r.validateTemplate(tpl); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+}
+
+func TestBuildQuery_StaticTemplate(t *testing.T) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{Name: "Open", Flag: "open", Description: "open PRs", Query: "is:pr is:open"}
+	mustValidate(t, r, tpl)
+	r.templates[tpl.Flag] = *tpl
+
+	got, err := r.BuildQuery("open", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "is:pr is:open" {
+		t.Errorf("got %q, want %q", got, "is:pr is:open")
+	}
+}
+
+func TestBuildQuery_ValueFunc(t *testing.T) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{
+		Name: "Author", Flag: "author", Description: "PRs by author",
+		QueryTemplate: "is:pr {{author .Value}}", Parameterized: true,
+	}
+	mustValidate(t, r, tpl)
+	r.templates[tpl.Flag] = *tpl
+
+	got, err := r.BuildQuery("author", "octocat", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "is:pr author:octocat"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBuildQuery_LabelsPipeline(t *testing.T) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{
+		Name: "Labels", Flag: "labels", Description: "PRs matching labels",
+		QueryTemplate:    "is:pr {{labels .Values}}",
+		Parameterized:    true,
+		SupportsMultiple: true,
+	}
+	mustValidate(t, r, tpl)
+	r.templates[tpl.Flag] = *tpl
+
+	got, err := r.BuildQuery("labels", "", []string{"lgtm", "-needs-rebase"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "is:pr label:lgtm -label:needs-rebase"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestValidateTemplate_RejectsValueWhenNotParameterized(t *testing.T) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{
+		Name: "Bad", Flag: "bad", Description: "broken",
+		QueryTemplate: "is:pr {{.Value}}",
+	}
+	if err := r.validateTemplate(tpl); err == nil {
+		t.Fatal("expected error for .Value reference without parameterized: true")
+	}
+}
+
+func TestValidateTemplate_RejectsRangeValuesWithoutSupportsMultiple(t *testing.T) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{
+		Name: "Bad", Flag: "bad", Description: "broken",
+		QueryTemplate: "{{range .Values}}{{.}}{{end}}", Parameterized: true,
+	}
+	if err := r.validateTemplate(tpl); err == nil {
+		t.Fatal("expected error for range .Values without supports_multiple: true")
+	}
+}
+
+func TestValidateTemplate_RejectsFuncsOnUserTemplates(t *testing.T) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{
+		Name: "Bad", Flag: "bad", Description: "broken", Query: "is:pr",
+		Funcs:  []string{"env"},
+		Source: "user",
+	}
+	if err := r.validateTemplate(tpl); err == nil {
+		t.Fatal("expected error for funcs on a user template")
+	}
+}
+
+func TestValidateTemplate_RejectsUnknownFunc(t *testing.T) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{
+		Name: "Bad", Flag: "bad", Description: "broken",
+		QueryTemplate: "{{env \"ORG\"}}", Parameterized: true,
+	}
+	if err := r.validateTemplate(tpl); err == nil {
+		t.Fatal("expected error for unlisted privileged func")
+	}
+}
+
+func TestValidateTemplate_AllowsPrivilegedFuncViaAllowList(t *testing.T) {
+	r := newTestRegistry()
+	tpl := &QueryTemplate{
+		Name: "OK", Flag: "ok", Description: "allowed", Source: "embedded",
+		QueryTemplate: "is:pr {{env \"ORG\"}}", Parameterized: true,
+		Funcs: []string{"env"},
+	}
+	if err := r.validateTemplate(tpl); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func addTemplate(t *testing.T, r *TemplateRegistry, tpl QueryTemplate) {
+	t.Helper()
+	mustValidate(t, r, &tpl)
+	r.templates[tpl.Flag] = tpl
+}
+
+func TestBuildQuery_ExtendsAndInclude(t *testing.T) {
+	r := newTestRegistry()
+	addTemplate(t, r, QueryTemplate{Name: "needs-lgtm", Flag: "needs-lgtm", Description: "needs lgtm", Query: "-label:lgtm"})
+	addTemplate(t, r, QueryTemplate{Name: "not-draft", Flag: "not-draft", Description: "not a draft", Query: "-is:draft", Source: "partial"})
+	addTemplate(t, r, QueryTemplate{
+		Name: "security-triage", Flag: "security-triage", Description: "security triage",
+		Query: "label:security", Extends: "needs-lgtm", Include: []string{"not-draft"},
+	})
+
+	got, err := r.BuildQuery("security-triage", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "-label:lgtm AND -is:draft AND label:security"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestResolve_DetectsCycle(t *testing.T) {
+	r := newTestRegistry()
+	addTemplate(t, r, QueryTemplate{Name: "a", Flag: "a", Description: "a", Query: "x", Extends: "b"})
+	addTemplate(t, r, QueryTemplate{Name: "b", Flag: "b", Description: "b", Query: "y", Extends: "a"})
+
+	if _, err := r.Resolve("a"); err == nil {
+		t.Fatal("expected cycle detection error")
+	}
+}
+
+func TestResolve_MissingReference(t *testing.T) {
+	r := newTestRegistry()
+	addTemplate(t, r, QueryTemplate{Name: "a", Flag: "a", Description: "a", Query: "x", Extends: "missing"})
+
+	if _, err := r.Resolve("a"); err == nil {
+		t.Fatal("expected missing-reference error")
+	}
+}
+
+func TestOrigin_ReportsSourceAndPath(t *testing.T) {
+	r := newTestRegistry()
+	r.roots = []string{"embedded", systemTemplatesDir, userTemplatesDir(), repoTemplatesDir()}
+	tpl := QueryTemplate{Name: "open", Flag: "open", Description: "open PRs", Query: "is:pr is:open", Source: "user", SourcePath: "/home/me/.config/autoprat/templates/open.yaml"}
+	mustValidate(t, r, &tpl)
+	r.templates[tpl.Flag] = tpl
+
+	root, path := r.Origin("open")
+	if root != "user" {
+		t.Errorf("expected root %q, got %q", "user", root)
+	}
+	if path != tpl.SourcePath {
+		t.Errorf("expected path %q, got %q", tpl.SourcePath, path)
+	}
+}
+
+func TestOrigin_UnknownFlag(t *testing.T) {
+	r := newTestRegistry()
+	if root, path := r.Origin("nope"); root != "" || path != "" {
+		t.Errorf("expected empty origin for unknown flag, got (%q, %q)", root, path)
+	}
+}
+
+func TestGetFlags_ExcludesPartials(t *testing.T) {
+	r := newTestRegistry()
+	addTemplate(t, r, QueryTemplate{Name: "open", Flag: "open", Description: "open PRs", Query: "is:pr is:open"})
+	addTemplate(t, r, QueryTemplate{Name: "not-draft", Flag: "not-draft", Query: "-is:draft", Source: "partial"})
+
+	flags := r.GetFlags()
+	if len(flags) != 1 || flags[0] != "open" {
+		t.Errorf("expected only [open], got %v", flags)
+	}
+}
@@ -0,0 +1,73 @@
+package github
+
+import "github.com/cli/go-gh"
+
+// GQLClient abstracts the GraphQL transport used to run search
+// queries, so it can be swapped for a fake in tests or a
+// custom-endpoint client for GitHub Enterprise, instead of hard-coding
+// gh.GQLClient(nil).
+type GQLClient interface {
+	Do(query string, vars map[string]any, resp any) error
+}
+
+// defaultGQLClient wraps go-gh's default GraphQL client.
+type defaultGQLClient struct {
+	client GQLClient
+}
+
+func newDefaultGQLClient() (GQLClient, error) {
+	client, err := gh.GQLClient(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &defaultGQLClient{client: client}, nil
+}
+
+func (c *defaultGQLClient) Do(query string, vars map[string]any, resp any) error {
+	return c.client.Do(query, vars, resp)
+}
+
+// Searcher runs PR searches against an injected GQLClient.
+type Searcher struct {
+	client GQLClient
+}
+
+// NewSearcher constructs a Searcher wrapping the default go-gh client.
+func NewSearcher() (*Searcher, error) {
+	client, err := newDefaultGQLClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Searcher{client: client}, nil
+}
+
+// NewSearcherWithClient constructs a Searcher against an injected
+// client, e.g. a fakeClient in tests or a GHE-targeted client.
+func NewSearcherWithClient(client GQLClient) *Searcher {
+	return &Searcher{client: client}
+}
+
+// Search runs query against a single `search` field and returns the
+// matching PRs.
+func (s *Searcher) Search(query string) ([]PullRequest, error) {
+	vars := map[string]any{"query": query}
+
+	var resp struct {
+		Search struct {
+			Nodes []graphQLPullRequest `json:"nodes"`
+		} `json:"search"`
+	}
+
+	if err := s.client.Do(searchPRQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return toPullRequests(resp.Search.Nodes), nil
+}
+
+// BatchSearch runs queries (keyed by caller-chosen bucket name) as one
+// or more aliased GraphQL documents against s's client. See
+// batchSearchWith for the chunking and document-building logic.
+func (s *Searcher) BatchSearch(queries map[string]string) (map[string][]PullRequest, error) {
+	return batchSearchWith(s.client, queries)
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// fakeClient implements GQLClient by returning canned JSON keyed by
+// the query document passed in, so Searcher can be exercised without
+// network access.
+type fakeClient struct {
+	responses map[string]string
+	err       error
+}
+
+func (f *fakeClient) Do(query string, vars map[string]any, resp any) error {
+	if f.err != nil {
+		return f.err
+	}
+	fixture, ok := f.responses[query]
+	if !ok {
+		return fmt.Errorf("fakeClient: no fixture registered for query %q", query)
+	}
+	return json.Unmarshal([]byte(fixture), resp)
+}
+
+func TestSearcher_Search_LabelExtraction(t *testing.T) {
+	fixture := `{
+		"search": {
+			"nodes": [
+				{
+					"number": 42,
+					"title": "Fix thing",
+					"url": "https://github.com/owner/repo/pull/42",
+					"labels": {"nodes": [{"name": "approved"}, {"name": "lgtm"}]}
+				}
+			]
+		}
+	}`
+
+	client := &fakeClient{responses: map[string]string{searchPRQuery: fixture}}
+	searcher := NewSearcherWithClient(client)
+
+	prs, err := searcher.Search("is:pr is:open")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("expected 1 PR, got %d", len(prs))
+	}
+	if got := prs[0].Labels; len(got) != 2 || got[0] != "approved" || got[1] != "lgtm" {
+		t.Errorf("expected labels [approved lgtm], got %v", got)
+	}
+	if prs[0].repo != "owner/repo" {
+		t.Errorf("expected repo extracted from URL, got %q", prs[0].repo)
+	}
+}
+
+func TestSearcher_Search_Empty(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{searchPRQuery: `{"search": {"nodes": []}}`}}
+	searcher := NewSearcherWithClient(client)
+
+	prs, err := searcher.Search("is:pr is:open")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 0 {
+		t.Errorf("expected no PRs, got %d", len(prs))
+	}
+}
+
+func TestSearcher_Search_MalformedTimestampDoesNotFail(t *testing.T) {
+	fixture := `{
+		"search": {
+			"nodes": [
+				{
+					"number": 1,
+					"url": "https://github.com/owner/repo/pull/1",
+					"createdAt": "not-a-timestamp",
+					"comments": {"nodes": [{"body": "hi", "createdAt": "also-not-a-timestamp"}]}
+				}
+			]
+		}
+	}`
+
+	client := &fakeClient{responses: map[string]string{searchPRQuery: fixture}}
+	searcher := NewSearcherWithClient(client)
+
+	prs, err := searcher.Search("is:pr is:open")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("expected 1 PR, got %d", len(prs))
+	}
+	if got := prs[0].LastCommentTime(); got != "" {
+		t.Errorf("expected empty LastCommentTime for malformed timestamp, got %q", got)
+	}
+}
+
+func TestSearcher_Search_PropagatesClientError(t *testing.T) {
+	client := &fakeClient{err: fmt.Errorf("boom")}
+	searcher := NewSearcherWithClient(client)
+
+	if _, err := searcher.Search("is:pr is:open"); err == nil {
+		t.Fatal("expected error to propagate from client")
+	}
+}
+
+func TestSearcher_BatchSearch_BucketsAndRateLimit(t *testing.T) {
+	queries := map[string]string{
+		"a": "is:pr author:@me",
+		"b": "is:pr review-requested:@me",
+	}
+
+	client := &recordingBatchClient{
+		resp: map[string]json.RawMessage{
+			alias(0):    json.RawMessage(`{"nodes": [{"number": 1, "url": "https://github.com/owner/repo/pull/1"}]}`),
+			alias(1):    json.RawMessage(`{"nodes": []}`),
+			"rateLimit": json.RawMessage(`{"cost": 2, "remaining": 4998, "resetAt": "2026-01-01T00:00:00Z"}`),
+		},
+	}
+
+	searcher := NewSearcherWithClient(client)
+	results, err := searcher.BatchSearch(queries)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(results))
+	}
+
+	rl := LastRateLimit()
+	if rl.Remaining != 4998 {
+		t.Errorf("expected LastRateLimit().Remaining == 4998, got %d", rl.Remaining)
+	}
+}
+
+// recordingBatchClient returns a fixed, alias-keyed response
+// regardless of the query text, letting batch tests avoid depending
+// on the exact generated GraphQL document.
+type recordingBatchClient struct {
+	resp map[string]json.RawMessage
+}
+
+func (c *recordingBatchClient) Do(query string, vars map[string]any, resp any) error {
+	target, ok := resp.(*map[string]json.RawMessage)
+	if !ok {
+		return fmt.Errorf("recordingBatchClient: unexpected resp type %T", resp)
+	}
+	*target = c.resp
+	return nil
+}
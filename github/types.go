@@ -0,0 +1,263 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PullRequest represents a minimal view of a GitHub PR for filtering,
+// listing, and acting on.
+type PullRequest struct {
+	Number            int
+	Title             string
+	Body              string
+	HeadRefName       string
+	CreatedAt         string
+	Labels            []string
+	AuthorLogin       string
+	AuthorType        string
+	URL               string
+	State             string
+	StatusCheckRollup StatusCheckRollup
+	Comments          []Comment
+
+	// Mergeable, MergeStateStatus, IsInMergeQueue, and
+	// IsMergeQueueEnabled mirror the corresponding GraphQL PullRequest
+	// fields and feed MergeState/OverallStatus.
+	Mergeable           string
+	MergeStateStatus    string
+	IsInMergeQueue      bool
+	IsMergeQueueEnabled bool
+
+	// RequiredStatusChecks lists the context/check names the base
+	// branch's protection rule (or ruleset) marks as required, fetched
+	// alongside the PR. It feeds RequiredCIStatus/OptionalCIStatus so
+	// flaky optional jobs don't block automation.
+	RequiredStatusChecks []string
+
+	// ChangedFiles lists the repo-relative paths touched by the PR,
+	// fetched alongside it. It feeds filters.FilterTypeFilesChangedGlob.
+	ChangedFiles []string
+
+	repo string
+}
+
+// StatusCheckRollup is the aggregate CI status for a PullRequest.
+type StatusCheckRollup struct {
+	Contexts struct {
+		Nodes []StatusCheck `json:"nodes"`
+	} `json:"contexts"`
+}
+
+// StatusCheck is a single CI check or status context.
+type StatusCheck struct {
+	Context    string `json:"context,omitempty"`
+	Name       string `json:"name,omitempty"`
+	State      string `json:"state"`
+	Conclusion string `json:"conclusion,omitempty"`
+	DetailsUrl string `json:"detailsUrl,omitempty"`
+	TargetUrl  string `json:"targetUrl,omitempty"`
+}
+
+// contextName returns whichever of Context/Name is set, the two names
+// GitHub uses for legacy status contexts vs. check runs respectively.
+func (c StatusCheck) contextName() string {
+	if c.Context != "" {
+		return c.Context
+	}
+	return c.Name
+}
+
+// providerPatterns maps well-known CI provider names to substrings
+// that commonly appear in their context/check names.
+var providerPatterns = []struct {
+	provider string
+	patterns []string
+}{
+	{"github-actions", []string{"github-actions", "github actions"}},
+	{"circleci", []string{"circleci", "circle-ci", "ci/circleci"}},
+	{"jenkins", []string{"jenkins"}},
+	{"appveyor", []string{"appveyor"}},
+	{"prow", []string{"ci/prow", "prow.k8s.io"}},
+	{"openshift-ci", []string{"ci/openshift"}},
+	{"travis", []string{"travis"}},
+	{"azure-pipelines", []string{"azure-pipelines", "vstfs:"}},
+}
+
+// Provider heuristically identifies which CI system reported c, by
+// matching well-known substrings in its context/check name. Returns
+// "unknown" if none match.
+func (c StatusCheck) Provider() string {
+	name := strings.ToLower(c.contextName())
+	for _, p := range providerPatterns {
+		for _, pattern := range p.patterns {
+			if strings.Contains(name, pattern) {
+				return p.provider
+			}
+		}
+	}
+	return "unknown"
+}
+
+// Comment is a single issue comment on a PullRequest.
+type Comment struct {
+	Body      string `json:"body"`
+	CreatedAt string `json:"createdAt"`
+	Author    struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+// CIStatus returns a summary of the CI status for the pull request,
+// across both required and optional checks. See RequiredCIStatus and
+// OptionalCIStatus to distinguish the two.
+func (pr PullRequest) CIStatus() string {
+	return statusFor(pr.StatusCheckRollup.Contexts.Nodes)
+}
+
+// RequiredCIStatus summarises the CI status of only the checks named
+// in RequiredStatusChecks, ignoring optional jobs. If
+// RequiredStatusChecks is empty (no branch protection rule, or it
+// couldn't be fetched), it falls back to CIStatus.
+func (pr PullRequest) RequiredCIStatus() string {
+	if len(pr.RequiredStatusChecks) == 0 {
+		return pr.CIStatus()
+	}
+	return statusFor(pr.requiredChecks())
+}
+
+// OptionalCIStatus summarises the CI status of checks NOT named in
+// RequiredStatusChecks, e.g. flaky third-party lint bots that
+// shouldn't block automation.
+func (pr PullRequest) OptionalCIStatus() string {
+	return statusFor(pr.optionalChecks())
+}
+
+func (pr PullRequest) requiredChecks() []StatusCheck {
+	var required []StatusCheck
+	for _, c := range pr.StatusCheckRollup.Contexts.Nodes {
+		if slices.Contains(pr.RequiredStatusChecks, c.contextName()) {
+			required = append(required, c)
+		}
+	}
+	return required
+}
+
+func (pr PullRequest) optionalChecks() []StatusCheck {
+	var optional []StatusCheck
+	for _, c := range pr.StatusCheckRollup.Contexts.Nodes {
+		if !slices.Contains(pr.RequiredStatusChecks, c.contextName()) {
+			optional = append(optional, c)
+		}
+	}
+	return optional
+}
+
+func statusFor(checks []StatusCheck) string {
+	for _, c := range checks {
+		st := c.State
+		if st == "" {
+			st = c.Conclusion
+		}
+		if st == "FAILURE" || st == "ACTION_REQUIRED" {
+			return "Failing"
+		}
+	}
+	for _, c := range checks {
+		st := c.State
+		if st == "" {
+			st = c.Conclusion
+		}
+		if st == "PENDING" {
+			return "Pending"
+		}
+	}
+	return "Passing"
+}
+
+// MergeState summarises pr's mergeability as a single word: InQueue,
+// Clean, Blocked, Behind, Dirty, Draft, Unstable, HasHooks, or Unknown. It
+// prioritises IsInMergeQueue over the raw MergeStateStatus, since a PR
+// already queued for merge is no longer waiting on anything else.
+func (pr PullRequest) MergeState() string {
+	if pr.IsInMergeQueue {
+		return "InQueue"
+	}
+	switch pr.MergeStateStatus {
+	case "CLEAN":
+		return "Clean"
+	case "BLOCKED":
+		return "Blocked"
+	case "BEHIND":
+		return "Behind"
+	case "DIRTY":
+		return "Dirty"
+	case "DRAFT":
+		return "Draft"
+	case "UNSTABLE":
+		return "Unstable"
+	case "HAS_HOOKS":
+		return "HasHooks"
+	default:
+		return "Unknown"
+	}
+}
+
+// OverallStatus combines CIStatus with MergeState so callers can tell
+// "checks passing but blocked by review" apart from "in merge queue"
+// and "unmergeable", instead of acting on green checks alone.
+func (pr PullRequest) OverallStatus() string {
+	if pr.IsInMergeQueue {
+		return "InQueue"
+	}
+
+	if ci := pr.CIStatus(); ci != "Passing" {
+		return ci
+	}
+
+	switch mergeState := pr.MergeState(); mergeState {
+	case "Clean":
+		return "Ready"
+	case "Unknown":
+		return "Passing"
+	default:
+		return mergeState
+	}
+}
+
+// Author returns the author name for display purposes.
+// For bots, shows the full "app/botname" format to match search expectations.
+func (pr PullRequest) Author() string {
+	if pr.AuthorType == "Bot" {
+		return "app/" + pr.AuthorLogin
+	}
+	return pr.AuthorLogin
+}
+
+// LastCommentTime returns a short, human-readable age for the most
+// recent comment, or "" if there are none.
+func (pr PullRequest) LastCommentTime() string {
+	if len(pr.Comments) == 0 {
+		return ""
+	}
+
+	last := pr.Comments[len(pr.Comments)-1]
+	createdAt, err := time.Parse(time.RFC3339, last.CreatedAt)
+	if err != nil {
+		return ""
+	}
+
+	timeSince := time.Since(createdAt)
+	switch {
+	case timeSince < time.Minute:
+		return "just now"
+	case timeSince < time.Hour:
+		return fmt.Sprintf("%dm", int(timeSince.Minutes()))
+	case timeSince < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(timeSince.Hours()), int(timeSince.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd", int(timeSince.Hours()/24))
+	}
+}
@@ -0,0 +1,64 @@
+package github
+
+import "testing"
+
+func TestRequiredAndOptionalCIStatus(t *testing.T) {
+	pr := PullRequest{
+		RequiredStatusChecks: []string{"ci/required-build"},
+		StatusCheckRollup: StatusCheckRollup{
+			Contexts: struct {
+				Nodes []StatusCheck `json:"nodes"`
+			}{
+				Nodes: []StatusCheck{
+					{Context: "ci/required-build", State: "SUCCESS"},
+					{Context: "ci/optional-lint", State: "FAILURE"},
+				},
+			},
+		},
+	}
+
+	if got := pr.RequiredCIStatus(); got != "Passing" {
+		t.Errorf("RequiredCIStatus() = %q, want Passing", got)
+	}
+	if got := pr.OptionalCIStatus(); got != "Failing" {
+		t.Errorf("OptionalCIStatus() = %q, want Failing", got)
+	}
+	if got := pr.CIStatus(); got != "Failing" {
+		t.Errorf("CIStatus() = %q, want Failing (combines both)", got)
+	}
+}
+
+func TestRequiredCIStatusFallsBackWithoutBranchProtection(t *testing.T) {
+	pr := PullRequest{
+		StatusCheckRollup: StatusCheckRollup{
+			Contexts: struct {
+				Nodes []StatusCheck `json:"nodes"`
+			}{
+				Nodes: []StatusCheck{{Context: "ci/build", State: "PENDING"}},
+			},
+		},
+	}
+
+	if got := pr.RequiredCIStatus(); got != "Pending" {
+		t.Errorf("RequiredCIStatus() = %q, want Pending (fallback to CIStatus)", got)
+	}
+}
+
+func TestStatusCheckProvider(t *testing.T) {
+	tests := []struct {
+		check StatusCheck
+		want  string
+	}{
+		{StatusCheck{Context: "ci/prow/e2e"}, "prow"},
+		{StatusCheck{Name: "github-actions / build"}, "github-actions"},
+		{StatusCheck{Context: "ci/circleci: test"}, "circleci"},
+		{StatusCheck{Context: "jenkins/pr-check"}, "jenkins"},
+		{StatusCheck{Context: "some-custom-bot"}, "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.check.Provider(); got != tt.want {
+			t.Errorf("Provider() for %+v = %q, want %q", tt.check, got, tt.want)
+		}
+	}
+}
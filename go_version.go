@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// minGoVersion is the oldest Go runtime autoprat supports. It tracks
+// the newest stdlib feature the code relies on: main.go's use of the
+// "slices" package requires Go 1.21.
+const minGoVersion = "go1.21"
+
+// GoVersionError reports that the running Go runtime is older than
+// the version autoprat requires.
+type GoVersionError struct {
+	Required string
+	Actual   string
+}
+
+func (e *GoVersionError) Error() string {
+	return fmt.Sprintf("autoprat requires Go %s or later, found %s", e.Required, e.Actual)
+}
+
+// RequireGoVersion returns a *GoVersionError if the running Go
+// runtime (runtime.Version()) is older than min (e.g. "go1.22"), so
+// callers can refuse to start rather than fail later on a missing
+// stdlib symbol. Versions RequireGoVersion can't parse, including
+// "devel" builds built from a VCS checkout rather than a release, are
+// treated as unknown and always allowed.
+func RequireGoVersion(min string) error {
+	return checkGoVersion(runtime.Version(), min)
+}
+
+func checkGoVersion(actual, min string) error {
+	actualVer, ok := parseGoVersion(actual)
+	if !ok {
+		return nil
+	}
+	minVer, ok := parseGoVersion(min)
+	if !ok {
+		return nil
+	}
+	if compareGoVersions(actualVer, minVer) < 0 {
+		return &GoVersionError{Required: min, Actual: actual}
+	}
+	return nil
+}
+
+// goVersion is a parsed "goMAJOR.MINOR.PATCH[pre]" version, e.g.
+// go1.22rc1 -> {major: 1, minor: 22, patch: 0, pre: "rc1"}.
+type goVersion struct {
+	major, minor, patch int
+	pre                 string
+}
+
+// parseGoVersion parses a runtime.Version()-style string ("go1.21",
+// "go1.21.5", "go1.22rc1", "go1.22beta1", "devel +abcdef123 ..."),
+// mirroring the handling go/types' GoVersion comparator uses for
+// release-candidate and beta suffixes. It reports ok=false for
+// "devel" builds and anything else it can't parse as a release
+// version.
+func parseGoVersion(v string) (goVersion, bool) {
+	v = strings.TrimPrefix(v, "go")
+	if v == "" || strings.HasPrefix(v, "devel") {
+		return goVersion{}, false
+	}
+	if i := strings.IndexByte(v, ' '); i >= 0 {
+		v = v[:i]
+	}
+
+	parts := strings.SplitN(v, ".", 3)
+	major, rest := splitLeadingDigits(parts[0])
+	if rest != "" {
+		return goVersion{}, false
+	}
+
+	gv := goVersion{major: major}
+	if len(parts) > 1 {
+		minor, rest := splitLeadingDigits(parts[1])
+		gv.minor = minor
+		gv.pre = rest
+	}
+	if len(parts) > 2 {
+		patch, rest := splitLeadingDigits(parts[2])
+		gv.patch = patch
+		if rest != "" {
+			gv.pre = rest
+		}
+	}
+	return gv, true
+}
+
+// splitLeadingDigits splits s into its leading run of digits (parsed
+// as an int, 0 if there is none) and the remaining suffix.
+func splitLeadingDigits(s string) (int, string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	n, _ := strconv.Atoi(s[:i])
+	return n, s[i:]
+}
+
+// compareGoVersions returns -1, 0, or 1 as a is less than, equal to,
+// or greater than b. A missing patch component is treated as 0, so
+// "go1.22" == "go1.22.0"; a pre-release suffix (rc1, beta1, ...)
+// sorts before the same release, so "go1.22rc1" < "go1.22.0".
+func compareGoVersions(a, b goVersion) int {
+	if a.major != b.major {
+		return cmpInt(a.major, b.major)
+	}
+	if a.minor != b.minor {
+		return cmpInt(a.minor, b.minor)
+	}
+	if a.patch != b.patch {
+		return cmpInt(a.patch, b.patch)
+	}
+	if a.pre == b.pre {
+		return 0
+	}
+	if a.pre == "" {
+		return 1
+	}
+	if b.pre == "" {
+		return -1
+	}
+	return strings.Compare(a.pre, b.pre)
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
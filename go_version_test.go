@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestCheckGoVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		actual  string
+		min     string
+		wantErr bool
+	}{
+		{name: "older minor version", actual: "go1.21", min: "go1.22", wantErr: true},
+		{name: "older minor version with patch", actual: "go1.21.5", min: "go1.22", wantErr: true},
+		{name: "release candidate is older than the release", actual: "go1.22rc1", min: "go1.22", wantErr: true},
+		{name: "exact match with implicit patch", actual: "go1.22.0", min: "go1.22", wantErr: false},
+		{name: "newer patch version", actual: "go1.22.3", min: "go1.22", wantErr: false},
+		{name: "devel build is unknown and always allowed", actual: "devel +abcdef Tue Jan 1 00:00:00 2024 +0000", min: "go1.22", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkGoVersion(tt.actual, tt.min)
+			if tt.wantErr && err == nil {
+				t.Fatalf("checkGoVersion(%q, %q) = nil, want an error", tt.actual, tt.min)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("checkGoVersion(%q, %q) = %v, want nil", tt.actual, tt.min, err)
+			}
+		})
+	}
+}
+
+func TestCheckGoVersion_UnparseableMinIsAllowed(t *testing.T) {
+	if err := checkGoVersion("go1.21", "devel"); err != nil {
+		t.Errorf("checkGoVersion with an unparseable min should be allowed, got: %v", err)
+	}
+}
+
+func TestGoVersionError(t *testing.T) {
+	err := &GoVersionError{Required: "go1.22", Actual: "go1.21"}
+	want := "autoprat requires Go go1.22 or later, found go1.21"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
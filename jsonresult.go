@@ -0,0 +1,127 @@
+package main
+
+// JSONSchemaVersion is the schema version of the structured output
+// produced by BuildJSONResult. Bump it whenever a field is removed or
+// changes meaning (additive fields don't require a bump).
+const JSONSchemaVersion = 2
+
+// JSONResult is the top-level structured output for --output=json and
+// --output=ndjson. In ndjson mode, each entry of Repositories[*].PRs is
+// emitted as its own line instead.
+type JSONResult struct {
+	SchemaVersion int              `json:"schema_version"`
+	Repositories  []JSONRepository `json:"repositories"`
+}
+
+// JSONRepository groups a repository's matched PRs.
+type JSONRepository struct {
+	Repository string   `json:"repository"`
+	PRs        []JSONPR `json:"prs"`
+}
+
+// JSONPR is the structured view of a single matched PR, including its
+// full CI status (StatusCheckRollup) and comment history, the action
+// commands that would be run, and a machine-readable reason for every
+// action that was considered but skipped.
+type JSONPR struct {
+	Number            int               `json:"number"`
+	Title             string            `json:"title"`
+	URL               string            `json:"url"`
+	Author            string            `json:"author"`
+	HeadRefName       string            `json:"head_ref_name"`
+	State             string            `json:"state"`
+	Labels            []string          `json:"labels"`
+	CIStatus          string            `json:"ci_status"`
+	LastCommented     string            `json:"last_commented,omitempty"`
+	StatusCheckRollup StatusCheckRollup `json:"status_check_rollup"`
+	Comments          []Comment         `json:"comments,omitempty"`
+	Commands          []string          `json:"commands,omitempty"`
+	SkippedActions    []SkippedAction   `json:"skipped_actions,omitempty"`
+}
+
+// SkippedAction records why an action was not applied to a PR.
+type SkippedAction struct {
+	Label  string `json:"label,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Skip reasons reported in SkippedAction.Reason.
+const (
+	SkipReasonLabelMissing = "label_missing"
+	SkipReasonLabelExists  = "label_exists"
+	SkipReasonSkipWhen     = "skip_when"
+	SkipReasonWhenNotMet   = "when_not_met"
+	SkipReasonThrottled    = "throttled"
+)
+
+// BuildJSONResult evaluates config.Actions against every PR in
+// filteredPRs, recording both the resulting commands and, for every
+// action that didn't fire, a machine-readable reason. It mirrors the
+// decision logic in FilterActions and Run's Actions branch, but keeps
+// the skipped actions visible instead of discarding them.
+func BuildJSONResult(filteredPRs []RepositoryPRs, config *Config) (JSONResult, error) {
+	result := JSONResult{SchemaVersion: JSONSchemaVersion}
+
+	for _, repoPRs := range filteredPRs {
+		jsonRepo := JSONRepository{Repository: repoPRs.Repository}
+
+		for _, prItem := range repoPRs.PRs {
+			jsonPR := JSONPR{
+				Number:            prItem.Number,
+				Title:             prItem.Title,
+				URL:               prItem.URL,
+				Author:            prItem.Author(),
+				HeadRefName:       prItem.HeadRefName,
+				State:             prItem.State,
+				Labels:            prItem.Labels,
+				CIStatus:          prItem.CIStatus(),
+				LastCommented:     prItem.LastCommentTime(),
+				StatusCheckRollup: prItem.StatusCheckRollup,
+				Comments:          prItem.Comments,
+			}
+
+			for _, a := range config.Actions {
+				hasLabel := contains(prItem.Labels, a.Label)
+				switch a.Predicate {
+				case PredicateSkipIfLabelExists:
+					if hasLabel {
+						jsonPR.SkippedActions = append(jsonPR.SkippedActions, SkippedAction{Label: a.Label, Reason: SkipReasonLabelExists})
+						continue
+					}
+				case PredicateOnlyIfLabelExists:
+					if !hasLabel {
+						jsonPR.SkippedActions = append(jsonPR.SkippedActions, SkippedAction{Label: a.Label, Reason: SkipReasonLabelMissing})
+						continue
+					}
+				}
+
+				if a.SkipWhen != nil && a.SkipWhen.Evaluate(prItem) {
+					jsonPR.SkippedActions = append(jsonPR.SkippedActions, SkippedAction{Label: a.Label, Reason: SkipReasonSkipWhen})
+					continue
+				}
+				if a.When != nil && !a.When.Evaluate(prItem) {
+					jsonPR.SkippedActions = append(jsonPR.SkippedActions, SkippedAction{Label: a.Label, Reason: SkipReasonWhenNotMet})
+					continue
+				}
+
+				comment, err := a.Render(prItem, repoPRs.Repository)
+				if err != nil {
+					return JSONResult{}, err
+				}
+
+				if config.Throttle > 0 && HasRecentComment(prItem, comment, config.Throttle) {
+					jsonPR.SkippedActions = append(jsonPR.SkippedActions, SkippedAction{Label: a.Label, Reason: SkipReasonThrottled})
+					continue
+				}
+
+				jsonPR.Commands = append(jsonPR.Commands, a.CommandWithComment(repoPRs.Repository, prItem.Number, comment))
+			}
+
+			jsonRepo.PRs = append(jsonRepo.PRs, jsonPR)
+		}
+
+		result.Repositories = append(result.Repositories, jsonRepo)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func testPRForJSON() PullRequest {
+	return PullRequest{
+		Number:      7,
+		Title:       "Fix flaky test",
+		URL:         "https://github.com/owner/repo/pull/7",
+		AuthorLogin: "octocat",
+		HeadRefName: "fix-flaky",
+		State:       "OPEN",
+		Labels:      []string{"approved"},
+	}
+}
+
+func TestBuildJSONResult_CommandsAndSkips(t *testing.T) {
+	filteredPRs := []RepositoryPRs{
+		{
+			Repository: "owner/repo",
+			PRs:        []PullRequest{testPRForJSON()},
+		},
+	}
+
+	config := &Config{
+		Actions: []Action{
+			{Comment: "/lgtm"},
+			{Comment: "/retest", Label: "approved", Predicate: PredicateSkipIfLabelExists},
+			{Comment: "/hold", Label: "needs-hold", Predicate: PredicateOnlyIfLabelExists},
+		},
+	}
+
+	result, err := BuildJSONResult(filteredPRs, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.SchemaVersion != JSONSchemaVersion {
+		t.Errorf("expected schema version %d, got %d", JSONSchemaVersion, result.SchemaVersion)
+	}
+	if len(result.Repositories) != 1 {
+		t.Fatalf("expected 1 repository, got %d", len(result.Repositories))
+	}
+
+	pr := result.Repositories[0].PRs[0]
+	if len(pr.Commands) != 1 || pr.Commands[0] == "" {
+		t.Fatalf("expected one command, got %v", pr.Commands)
+	}
+	if len(pr.SkippedActions) != 2 {
+		t.Fatalf("expected 2 skipped actions, got %v", pr.SkippedActions)
+	}
+	if pr.SkippedActions[0].Reason != SkipReasonLabelExists {
+		t.Errorf("expected label_exists reason, got %s", pr.SkippedActions[0].Reason)
+	}
+	if pr.SkippedActions[1].Reason != SkipReasonLabelMissing {
+		t.Errorf("expected label_missing reason, got %s", pr.SkippedActions[1].Reason)
+	}
+}
+
+func TestJSONFormatter_Golden(t *testing.T) {
+	result := JSONResult{
+		SchemaVersion: JSONSchemaVersion,
+		Repositories: []JSONRepository{
+			{
+				Repository: "owner/repo",
+				PRs: []JSONPR{
+					{
+						Number:         7,
+						Title:          "Fix flaky test",
+						URL:            "https://github.com/owner/repo/pull/7",
+						Author:         "octocat",
+						HeadRefName:    "fix-flaky",
+						State:          "OPEN",
+						Labels:         []string{"approved"},
+						Commands:       []string{`gh pr comment --repo owner/repo 7 --body "/lgtm"`},
+						SkippedActions: []SkippedAction{{Label: "needs-hold", Reason: SkipReasonLabelMissing}},
+					},
+				},
+			},
+		},
+	}
+
+	stdout := captureStdout(t, func() {
+		f := &JSONFormatter{}
+		if err := f.Format(result, &Config{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var got JSONResult
+	if err := json.Unmarshal(stdout, &got); err != nil {
+		t.Fatalf("failed to parse formatter output: %v", err)
+	}
+	if !jsonEqual(t, got, result) {
+		t.Errorf("formatter output does not round-trip: got %+v, want %+v", got, result)
+	}
+}
+
+func TestJSONFormatter_NDJSON(t *testing.T) {
+	result := JSONResult{
+		SchemaVersion: JSONSchemaVersion,
+		Repositories: []JSONRepository{
+			{Repository: "owner/a", PRs: []JSONPR{{Number: 1}}},
+			{Repository: "owner/b", PRs: []JSONPR{{Number: 2}, {Number: 3}}},
+		},
+	}
+
+	stdout := captureStdout(t, func() {
+		f := &JSONFormatter{NDJSON: true}
+		if err := f.Format(result, &Config{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	lines := bytes.Count(bytes.TrimRight(stdout, "\n"), []byte("\n")) + 1
+	if lines != 3 {
+		t.Fatalf("expected 3 ndjson lines, got %d (%s)", lines, stdout)
+	}
+}
+
+func captureStdout(t *testing.T, fn func()) []byte {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.Bytes()
+}
+
+func jsonEqual(t *testing.T, a, b JSONResult) bool {
+	t.Helper()
+	aBytes, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("failed to marshal a: %v", err)
+	}
+	bBytes, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("failed to marshal b: %v", err)
+	}
+	return bytes.Equal(aBytes, bBytes)
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LogExtractor pulls the interesting (failure) portion out of raw CI
+// log content for one output format.
+type LogExtractor interface {
+	// Detect reports whether this extractor recognises content's
+	// format.
+	Detect(content string) bool
+	// Extract returns the filtered summary for content. An empty
+	// result means the extractor recognised the format but found
+	// nothing worth reporting.
+	Extract(content string) string
+}
+
+// logExtractors is tried in order; the first extractor that detects
+// content's format and extracts something non-empty is used. An
+// extractor that detects the format but finds nothing passes on to the
+// next one, and if none produces output, the generic regex-based scan
+// in filterErrorLogs is used.
+var logExtractors = []LogExtractor{
+	junitXMLExtractor{},
+	goTestExtractor{},
+	ginkgoExtractor{},
+}
+
+// extractErrorLogs filters raw CI log content down to its failures,
+// recognising Go test, Ginkgo, and JUnit XML output before falling
+// back to a generic error-line scan.
+func extractErrorLogs(content string) string {
+	for _, e := range logExtractors {
+		if e.Detect(content) {
+			if out := e.Extract(content); out != "" {
+				return out
+			}
+		}
+	}
+	return filterErrorLogs(content)
+}
+
+// filterErrorLogs extracts lines that look like errors from log content
+func filterErrorLogs(content string) string {
+	lines := strings.Split(content, "\n")
+	var errorLines []string
+
+	errorPatterns := []*regexp.Regexp{
+		regexp.MustCompile(`(?i)(error|failed|failure|fatal|panic):`),
+		regexp.MustCompile(`(?i)\b(error|fail|exception)\b`),
+		regexp.MustCompile(`^\s*\+\s*.*error`),
+		regexp.MustCompile(`^\s*E\s+`),
+		regexp.MustCompile(`^\s*FAIL\s+`),
+		regexp.MustCompile(`exit\s+code\s+[1-9]`),
+	}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if len(line) > 500 {
+			continue
+		}
+
+		for _, pattern := range errorPatterns {
+			if pattern.MatchString(line) {
+				errorLines = append(errorLines, "    "+line)
+				break
+			}
+		}
+	}
+
+	if len(errorLines) > 20 {
+		errorLines = errorLines[:20]
+		errorLines = append(errorLines, "    ... (truncated)")
+	}
+
+	if len(errorLines) == 0 {
+		return ""
+	}
+
+	return strings.Join(errorLines, "\n")
+}
+
+// goTestFailLine matches the start of a `go test` failure, e.g.
+// "--- FAIL: TestFoo (0.01s)".
+var goTestFailLine = regexp.MustCompile(`^--- FAIL: `)
+
+// goTestExtractor recognises `go test` output and extracts each
+// "--- FAIL:" block along with its indented diagnostic lines.
+type goTestExtractor struct{}
+
+func (goTestExtractor) Detect(content string) bool {
+	return goTestFailLine.MatchString(content) || strings.Contains(content, "\n--- FAIL: ")
+}
+
+func (goTestExtractor) Extract(content string) string {
+	lines := strings.Split(content, "\n")
+	var blocks []string
+	var current []string
+
+	flush := func() {
+		if len(current) > 0 {
+			blocks = append(blocks, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+
+	for _, line := range lines {
+		if goTestFailLine.MatchString(line) {
+			flush()
+			current = append(current, line)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
+			current = append(current, line)
+			continue
+		}
+		// A non-indented, non-"--- FAIL:" line ends the block.
+		flush()
+	}
+	flush()
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// ginkgoFailMarker matches a Ginkgo failure header, e.g.
+// "• [FAILED] [0.012 seconds]" or "[FAILED] some message".
+var ginkgoFailMarker = regexp.MustCompile(`\[FAILED\]`)
+
+// ginkgoSeparator matches the "---" rule Ginkgo prints between
+// specs.
+var ginkgoSeparator = regexp.MustCompile(`^-{10,}$`)
+
+// ginkgoExtractor recognises Ginkgo spec output and extracts each
+// "[FAILED]" block up to the next separator line.
+type ginkgoExtractor struct{}
+
+func (ginkgoExtractor) Detect(content string) bool {
+	return ginkgoFailMarker.MatchString(content)
+}
+
+func (ginkgoExtractor) Extract(content string) string {
+	lines := strings.Split(content, "\n")
+	var blocks []string
+	var current []string
+	capturing := false
+
+	flush := func() {
+		if len(current) > 0 {
+			blocks = append(blocks, strings.Join(current, "\n"))
+			current = nil
+		}
+		capturing = false
+	}
+
+	for _, line := range lines {
+		if ginkgoFailMarker.MatchString(line) {
+			flush()
+			capturing = true
+			current = append(current, line)
+			continue
+		}
+		if capturing {
+			if ginkgoSeparator.MatchString(strings.TrimSpace(line)) {
+				flush()
+				continue
+			}
+			current = append(current, line)
+		}
+	}
+	flush()
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// junitSuites is the root of a JUnit XML report when it wraps one or
+// more <testsuite> elements in <testsuites>.
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name  string      `xml:"name,attr"`
+	Cases []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name    string        `xml:"name,attr"`
+	Failure *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// junitXMLExtractor recognises JUnit XML test reports (the format
+// produced by `go-junit-report`, pytest, and most CI test runners) and
+// extracts each failing testcase's message.
+type junitXMLExtractor struct{}
+
+func (junitXMLExtractor) Detect(content string) bool {
+	trimmed := strings.TrimSpace(content)
+	return strings.Contains(trimmed[:min(len(trimmed), 200)], "<?xml") && strings.Contains(content, "<testsuite")
+}
+
+func (junitXMLExtractor) Extract(content string) string {
+	var suites []junitSuite
+
+	var root junitSuites
+	if err := xml.Unmarshal([]byte(content), &root); err == nil && len(root.Suites) > 0 {
+		suites = root.Suites
+	} else {
+		var single junitSuite
+		if err := xml.Unmarshal([]byte(content), &single); err != nil {
+			return ""
+		}
+		suites = []junitSuite{single}
+	}
+
+	var failures []string
+	for _, suite := range suites {
+		for _, c := range suite.Cases {
+			if c.Failure == nil {
+				continue
+			}
+			detail := strings.TrimSpace(c.Failure.Message)
+			if detail == "" {
+				detail = strings.TrimSpace(c.Failure.Body)
+			}
+			failures = append(failures, fmt.Sprintf("%s: %s", c.Name, detail))
+		}
+	}
+
+	return strings.Join(failures, "\n")
+}
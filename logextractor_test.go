@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoTestExtractor(t *testing.T) {
+	content := "=== RUN   TestFoo\n--- FAIL: TestFoo (0.01s)\n    foo_test.go:12: expected 1, got 2\n=== RUN   TestBar\n--- PASS: TestBar (0.00s)\nFAIL\n"
+
+	e := goTestExtractor{}
+	if !e.Detect(content) {
+		t.Fatal("expected Detect to recognise go test FAIL output")
+	}
+
+	got := e.Extract(content)
+	if got == "" {
+		t.Fatal("expected a non-empty extract")
+	}
+	if !strings.Contains(got, "--- FAIL: TestFoo") || !strings.Contains(got, "expected 1, got 2") {
+		t.Errorf("extract missing expected content: %q", got)
+	}
+	if strings.Contains(got, "TestBar") {
+		t.Errorf("extract should not include passing tests: %q", got)
+	}
+}
+
+func TestGinkgoExtractor(t *testing.T) {
+	content := "• [FAILED] [0.01 seconds]\nSome Spec\nExpected foo to equal bar\n------------------------------\n• [PASSED]\n"
+
+	e := ginkgoExtractor{}
+	if !e.Detect(content) {
+		t.Fatal("expected Detect to recognise a Ginkgo [FAILED] block")
+	}
+
+	got := e.Extract(content)
+	if !strings.Contains(got, "Expected foo to equal bar") {
+		t.Errorf("extract missing failure detail: %q", got)
+	}
+	if strings.Contains(got, "[PASSED]") {
+		t.Errorf("extract should not include passing specs: %q", got)
+	}
+}
+
+func TestJUnitXMLExtractor(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="pkg">
+  <testcase name="TestOK"></testcase>
+  <testcase name="TestBad"><failure message="assertion failed">want 1 got 2</failure></testcase>
+</testsuite>`
+
+	e := junitXMLExtractor{}
+	if !e.Detect(content) {
+		t.Fatal("expected Detect to recognise JUnit XML")
+	}
+
+	got := e.Extract(content)
+	if !strings.Contains(got, "TestBad") || !strings.Contains(got, "assertion failed") {
+		t.Errorf("extract missing failing testcase: %q", got)
+	}
+	if strings.Contains(got, "TestOK") {
+		t.Errorf("extract should not include passing testcases: %q", got)
+	}
+}
+
+func TestExtractErrorLogsFallsBackToGenericScan(t *testing.T) {
+	content := "line one\nerror: something broke\nline three\n"
+	got := extractErrorLogs(content)
+	if !strings.Contains(got, "error: something broke") {
+		t.Errorf("expected generic fallback to find the error line, got %q", got)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// LogProvider knows how to recognise and fetch raw log output for one
+// kind of CI system, identified by the shape of a StatusCheck's
+// DetailsUrl/TargetUrl.
+type LogProvider interface {
+	// Matches reports whether this provider can fetch logs for check.
+	Matches(check StatusCheck) bool
+	// FetchRaw returns check's raw (unfiltered) log content.
+	FetchRaw(check StatusCheck) (string, error)
+}
+
+func checkURL(check StatusCheck) string {
+	if check.DetailsUrl != "" {
+		return check.DetailsUrl
+	}
+	return check.TargetUrl
+}
+
+// prowLogProvider fetches build-log.txt for OpenShift/Kubernetes Prow
+// jobs, whose DetailsUrl points at a GCS viewer page rather than the
+// raw log.
+type prowLogProvider struct{}
+
+func (prowLogProvider) Matches(check StatusCheck) bool {
+	return strings.Contains(checkURL(check), "prow.ci.openshift.org/view/gs/")
+}
+
+func (prowLogProvider) FetchRaw(check StatusCheck) (string, error) {
+	url := strings.Replace(checkURL(check), "prow.ci.openshift.org/view/gs/", "storage.googleapis.com/", 1)
+	if !strings.HasSuffix(url, "/build-log.txt") {
+		url += "/build-log.txt"
+	}
+	return httpGet(url)
+}
+
+// githubActionsRunJobURL matches a GitHub Actions job's web URL, e.g.
+// "https://github.com/owner/repo/actions/runs/123/job/456".
+var githubActionsRunJobURL = regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/actions/runs/\d+/job/(\d+)`)
+
+// githubActionsLogProvider fetches job logs via the GitHub REST API's
+// actions/jobs/{job_id}/logs endpoint, which redirects to the raw log
+// archive.
+type githubActionsLogProvider struct{}
+
+func (githubActionsLogProvider) Matches(check StatusCheck) bool {
+	return githubActionsRunJobURL.MatchString(checkURL(check))
+}
+
+func (githubActionsLogProvider) FetchRaw(check StatusCheck) (string, error) {
+	m := githubActionsRunJobURL.FindStringSubmatch(checkURL(check))
+	if m == nil {
+		return "", fmt.Errorf("could not parse GitHub Actions job URL: %s", checkURL(check))
+	}
+	owner, repo, jobID := m[1], m[2], m[3]
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/actions/jobs/%s/logs", owner, repo, jobID)
+	return httpGet(url)
+}
+
+// gitlabJobURL matches a GitLab CI job's web URL, e.g.
+// "https://gitlab.com/owner/repo/-/jobs/123".
+var gitlabJobURL = regexp.MustCompile(`https?://([^/]+)/(.+)/-/jobs/(\d+)`)
+
+// gitlabCILogProvider fetches a job's trace (its combined log output)
+// via the GitLab REST (v4) API, authenticated via GITLAB_TOKEN.
+type gitlabCILogProvider struct{}
+
+func (gitlabCILogProvider) Matches(check StatusCheck) bool {
+	return gitlabJobURL.MatchString(checkURL(check))
+}
+
+func (gitlabCILogProvider) FetchRaw(check StatusCheck) (string, error) {
+	m := gitlabJobURL.FindStringSubmatch(checkURL(check))
+	if m == nil {
+		return "", fmt.Errorf("could not parse GitLab CI job URL: %s", checkURL(check))
+	}
+	host, project, jobID := m[1], m[2], m[3]
+
+	url := fmt.Sprintf("https://%s/api/v4/projects/%s/jobs/%s/trace", host, pathEscape(project), jobID)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch logs from %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("received status %d from %s", resp.StatusCode, url)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// pathEscape percent-encodes project for use as GitLab's :id path
+// segment (GitLab accepts "owner%2Frepo" in place of a numeric ID).
+func pathEscape(project string) string {
+	return strings.ReplaceAll(project, "/", "%2F")
+}
+
+// rawURLLogProvider is the fallback for any check whose URL already
+// looks like it serves raw log text.
+type rawURLLogProvider struct{}
+
+func (rawURLLogProvider) Matches(check StatusCheck) bool {
+	url := checkURL(check)
+	if url == "" {
+		return false
+	}
+	if strings.Contains(url, "github.com") && strings.Contains(url, "#issuecomment") {
+		return false
+	}
+	return strings.Contains(url, "storage.googleapis.com") || strings.Contains(url, "raw")
+}
+
+func (rawURLLogProvider) FetchRaw(check StatusCheck) (string, error) {
+	return httpGet(checkURL(check))
+}
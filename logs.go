@@ -4,33 +4,43 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"regexp"
-	"strings"
 
 	"github.com/cli/go-gh"
 )
 
-// FetchCheckLogs retrieves and filters error logs from a failing check
+// logProviders is tried in order against a failing check; the first
+// provider whose Matches returns true fetches the raw log.
+var logProviders = []LogProvider{
+	prowLogProvider{},
+	githubActionsLogProvider{},
+	gitlabCILogProvider{},
+	rawURLLogProvider{},
+}
+
+// FetchCheckLogs retrieves and filters error logs from a failing
+// check, dispatching to whichever LogProvider in logProviders
+// recognises it.
 func (pr *PullRequest) FetchCheckLogs(check StatusCheck) (string, error) {
-	url := check.DetailsUrl
-	if url == "" {
-		url = check.TargetUrl
-	}
-	if url == "" {
-		return "", fmt.Errorf("no URL available for check logs")
-	}
+	for _, provider := range logProviders {
+		if !provider.Matches(check) {
+			continue
+		}
 
-	if strings.Contains(url, "prow.ci.openshift.org/view/gs/") {
-		url = strings.Replace(url, "prow.ci.openshift.org/view/gs/", "storage.googleapis.com/", 1)
-		if !strings.HasSuffix(url, "/build-log.txt") {
-			url = url + "/build-log.txt"
+		body, err := provider.FetchRaw(check)
+		if err != nil {
+			return "", err
 		}
-	} else if strings.Contains(url, "github.com") && strings.Contains(url, "#issuecomment") {
-		return "", fmt.Errorf("GitHub comment URL does not contain raw logs")
-	} else if !strings.Contains(url, "storage.googleapis.com") && !strings.Contains(url, "raw") {
-		return "", fmt.Errorf("URL does not appear to contain raw logs: %s", url)
+
+		return extractErrorLogs(body), nil
 	}
 
+	return "", fmt.Errorf("no log provider recognises this check's URL")
+}
+
+// httpGet performs an authenticated GET via gh's HTTP client (so
+// api.github.com and raw GitHub URLs pick up gh's credentials) and
+// returns the response body.
+func httpGet(url string) (string, error) {
 	client, err := gh.HTTPClient(nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create HTTP client: %w", err)
@@ -38,7 +48,7 @@ func (pr *PullRequest) FetchCheckLogs(check StatusCheck) (string, error) {
 
 	resp, err := client.Get(url)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch check logs from %s: %w", url, err)
+		return "", fmt.Errorf("failed to fetch logs from %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
@@ -51,49 +61,5 @@ func (pr *PullRequest) FetchCheckLogs(check StatusCheck) (string, error) {
 		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return filterErrorLogs(string(body)), nil
-}
-
-// filterErrorLogs extracts lines that look like errors from log content
-func filterErrorLogs(content string) string {
-	lines := strings.Split(content, "\n")
-	var errorLines []string
-
-	errorPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?i)(error|failed|failure|fatal|panic):`),
-		regexp.MustCompile(`(?i)\b(error|fail|exception)\b`),
-		regexp.MustCompile(`^\s*\+\s*.*error`),
-		regexp.MustCompile(`^\s*E\s+`),
-		regexp.MustCompile(`^\s*FAIL\s+`),
-		regexp.MustCompile(`exit\s+code\s+[1-9]`),
-	}
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		if len(line) > 500 {
-			continue
-		}
-
-		for _, pattern := range errorPatterns {
-			if pattern.MatchString(line) {
-				errorLines = append(errorLines, "    "+line)
-				break
-			}
-		}
-	}
-
-	if len(errorLines) > 20 {
-		errorLines = errorLines[:20]
-		errorLines = append(errorLines, "    ... (truncated)")
-	}
-
-	if len(errorLines) == 0 {
-		return ""
-	}
-
-	return strings.Join(errorLines, "\n")
+	return string(body), nil
 }
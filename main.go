@@ -19,16 +19,42 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"slices"
+
+	"github.com/frobware/autoprat/github/search"
 )
 
 func main() {
+	// Refuse to run on an unsupported Go runtime rather than fail
+	// later with a confusing missing-stdlib-symbol error.
+	if err := RequireGoVersion(minGoVersion); err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	// "autoprat templates ..." is a standalone subcommand family that
+	// doesn't need PR data or the GitHub client, so it's dispatched
+	// before the usual flag/registry setup below.
+	if len(os.Args) > 1 && os.Args[1] == "templates" {
+		if err := runTemplatesCommand(os.Args[2:]); err != nil {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+
 	// Create registries and extract plain data
 	actionRegistry, err := NewRegistry()
 	if err != nil {
 		log.Fatalf("Failed to load action registry: %v", err)
 	}
 
-	templateRegistry, err := NewTemplateRegistry()
+	// --refresh-templates/--offline-templates affect how the template
+	// registry's remote sources are loaded, so they must be read
+	// before the registry (and the CLI flags it feeds) is built.
+	templateRegistry, err := search.NewTemplateRegistryWithMode(search.TemplateLoadOptions{
+		Refresh: slices.Contains(os.Args[1:], "--refresh-templates"),
+		Offline: slices.Contains(os.Args[1:], "--offline-templates"),
+	})
 	if err != nil {
 		log.Fatalf("Failed to load template registry: %v", err)
 	}
@@ -46,11 +72,24 @@ func main() {
 	// Create context
 	ctx := context.Background()
 
-	// Create client factory
+	// Create client factory, selecting the gh CLI or native API
+	// backend per --backend.
 	clientFactory := func(repo string) (GitHubClient, error) {
+		if config.Backend == "api" {
+			return newAPIBackedClient(repo)
+		}
 		return NewClient(repo)
 	}
 
+	// --watch switches from a single pass to a long-running reconcile
+	// loop, so it bypasses Run/FormatResult entirely.
+	if config.WatchInterval > 0 {
+		if err := RunWatch(ctx, config, clientFactory); err != nil {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+
 	// Run the core application logic
 	result, err := Run(ctx, config, clientFactory)
 	if err != nil {
@@ -1,21 +1,138 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 )
 
-// CommandFormatter outputs commands as-is.
+// CommandFormatter outputs commands as-is, or as structured
+// "pr"/"command" JSON or NDJSON records when config.Output requests it,
+// so orchestrators can execute and retry each command under their own
+// concurrency policy instead of shelling out to bash.
 type CommandFormatter struct{}
 
-// Format outputs commands for execution.
+// Format outputs commands for execution, in the style config.Output
+// selects.
 func (f *CommandFormatter) Format(result Result, config *Config) error {
 	cmdResult, ok := result.(CommandResult)
 	if !ok {
 		return fmt.Errorf("CommandFormatter expects CommandResult, got %T", result)
 	}
 
-	for _, cmd := range cmdResult.Commands {
-		fmt.Println(cmd)
+	switch config.Output {
+	case "", "table", "verbose", "quiet":
+		for _, entry := range cmdResult.Commands {
+			fmt.Println(entry.Command)
+		}
+		return nil
+	case "ndjson":
+		enc := json.NewEncoder(os.Stdout)
+		for _, entry := range cmdResult.Commands {
+			if err := enc.Encode(entry); err != nil {
+				return fmt.Errorf("failed to encode ndjson command: %w", err)
+			}
+		}
+		return nil
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(cmdResult.Commands)
+	default:
+		return fmt.Errorf("unknown --output %q, expected json or ndjson", config.Output)
+	}
+}
+
+// WorkflowFormatter prints the per-PR workflow plan produced when
+// actions declare multi-step workflows.
+type WorkflowFormatter struct{}
+
+// Format prints, per PR, which workflow steps ran and which are still
+// pending (and why), so the plan is visible before anything is
+// posted.
+func (f *WorkflowFormatter) Format(result Result, config *Config) error {
+	results, ok := result.([]WorkflowResult)
+	if !ok {
+		return fmt.Errorf("WorkflowFormatter expects []WorkflowResult, got %T", result)
+	}
+
+	for _, wr := range results {
+		fmt.Printf("%s#%d\n", wr.Repository, wr.PRNumber)
+		for _, step := range wr.Steps {
+			if step.Ran {
+				fmt.Printf("  [ran]     %s: %s\n", step.Flag, step.Comment)
+			} else {
+				fmt.Printf("  [pending] %s: %s (%s)\n", step.Flag, step.Comment, step.Reason)
+			}
+		}
+	}
+	return nil
+}
+
+// ContextFormatter dumps the rendered comment-template context for each
+// matched PR, for debugging `comment:` YAML templates.
+type ContextFormatter struct{}
+
+// Format prints each PR's CommentContext as key: value pairs.
+func (f *ContextFormatter) Format(result Result, config *Config) error {
+	ctxResult, ok := result.(ContextResult)
+	if !ok {
+		return fmt.Errorf("ContextFormatter expects ContextResult, got %T", result)
+	}
+
+	for _, prCtx := range ctxResult.Contexts {
+		c := prCtx.Context
+		fmt.Printf("%s#%d\n", prCtx.Repository, prCtx.PRNumber)
+		fmt.Printf("  Number:          %d\n", c.Number)
+		fmt.Printf("  Title:           %s\n", c.Title)
+		fmt.Printf("  Author:          %s\n", c.Author)
+		fmt.Printf("  HeadRefName:     %s\n", c.HeadRefName)
+		fmt.Printf("  Labels:          %v\n", c.Labels)
+		fmt.Printf("  CIStatus:        %s\n", c.CIStatus)
+		fmt.Printf("  FailingChecks:   %v\n", c.FailingChecks)
+		fmt.Printf("  LastCommentTime: %s\n", c.LastCommentTime)
+		fmt.Printf("  RepoOwner:       %s\n", c.RepoOwner)
+		fmt.Printf("  RepoName:        %s\n", c.RepoName)
+	}
+	return nil
+}
+
+// JSONFormatter serializes a JSONResult as either a single pretty-printed
+// JSON document (--output=json) or one JSON object per PR
+// (--output=ndjson), for scripting against autoprat's output.
+type JSONFormatter struct {
+	NDJSON bool
+}
+
+// Format writes jsonResult to stdout in the configured style.
+func (f *JSONFormatter) Format(result Result, config *Config) error {
+	jsonResult, ok := result.(JSONResult)
+	if !ok {
+		return fmt.Errorf("JSONFormatter expects JSONResult, got %T", result)
+	}
+
+	if !f.NDJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(jsonResult)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for _, repo := range jsonResult.Repositories {
+		for _, pr := range repo.PRs {
+			record := struct {
+				SchemaVersion int    `json:"schema_version"`
+				Repository    string `json:"repository"`
+				JSONPR
+			}{
+				SchemaVersion: jsonResult.SchemaVersion,
+				Repository:    repo.Repository,
+				JSONPR:        pr,
+			}
+			if err := enc.Encode(record); err != nil {
+				return fmt.Errorf("failed to encode ndjson record: %w", err)
+			}
+		}
 	}
 	return nil
 }
@@ -26,13 +143,29 @@ func FormatResult(result Result, config *Config) error {
 	case CommandResult:
 		formatter := &CommandFormatter{}
 		return formatter.Format(result, config)
+	case ContextResult:
+		formatter := &ContextFormatter{}
+		return formatter.Format(result, config)
+	case JSONResult:
+		formatter := &JSONFormatter{NDJSON: config.Output == "ndjson"}
+		return formatter.Format(result, config)
+	case []WorkflowResult:
+		formatter := &WorkflowFormatter{}
+		return formatter.Format(result, config)
 	case PRResult:
 		var formatter Formatter
-		if config.Detailed || config.DetailedWithLogs {
+		switch {
+		case config.Output == "verbose":
+			formatter = &VerboseFormatter{}
+		case config.Output == "quiet":
+			formatter = &QuietFormatter{}
+		case config.Output == "table":
+			formatter = &TabularFormatter{}
+		case config.Detailed || config.DetailedWithLogs:
 			formatter = &VerboseFormatter{}
-		} else if config.Quiet {
+		case config.Quiet:
 			formatter = &QuietFormatter{}
-		} else {
+		default:
 			formatter = &TabularFormatter{}
 		}
 		return formatter.Format(result, config)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"strings"
@@ -122,9 +123,9 @@ func TestCommandFormatter(t *testing.T) {
 
 	// Create test data
 	result := CommandResult{
-		Commands: []string{
-			"gh pr review --approve 123",
-			"gh pr review --comment '/lgtm' 456",
+		Commands: []CommandEntry{
+			{PR: 123, Command: "gh pr review --approve 123"},
+			{PR: 456, Command: "gh pr review --comment '/lgtm' 456"},
 		},
 	}
 
@@ -154,6 +155,50 @@ func TestCommandFormatter(t *testing.T) {
 	}
 }
 
+func TestCommandFormatter_JSON(t *testing.T) {
+	result := CommandResult{
+		Commands: []CommandEntry{
+			{PR: 123, Command: `gh pr comment --repo owner/repo 123 --body "/lgtm"`},
+		},
+	}
+
+	stdout := captureStdout(t, func() {
+		f := &CommandFormatter{}
+		if err := f.Format(result, &Config{Output: "json"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var got []CommandEntry
+	if err := json.Unmarshal(stdout, &got); err != nil {
+		t.Fatalf("failed to parse formatter output: %v", err)
+	}
+	if len(got) != 1 || got[0] != result.Commands[0] {
+		t.Errorf("got %+v, want %+v", got, result.Commands)
+	}
+}
+
+func TestCommandFormatter_NDJSON(t *testing.T) {
+	result := CommandResult{
+		Commands: []CommandEntry{
+			{PR: 1, Command: "cmd one"},
+			{PR: 2, Command: "cmd two"},
+		},
+	}
+
+	stdout := captureStdout(t, func() {
+		f := &CommandFormatter{}
+		if err := f.Format(result, &Config{Output: "ndjson"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	lines := bytes.Count(bytes.TrimRight(stdout, "\n"), []byte("\n")) + 1
+	if lines != 2 {
+		t.Fatalf("expected 2 ndjson lines, got %d (%s)", lines, stdout)
+	}
+}
+
 func TestFormatResult(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -165,7 +210,7 @@ func TestFormatResult(t *testing.T) {
 		{
 			name: "CommandResult",
 			result: CommandResult{
-				Commands: []string{"test command"},
+				Commands: []CommandEntry{{PR: 1, Command: "test command"}},
 			},
 			config:         &Config{},
 			expectError:    false,
@@ -198,6 +243,24 @@ func TestFormatResult(t *testing.T) {
 			expectError:    false,
 			expectedFormat: "verbose",
 		},
+		{
+			name: "PRResult - Output=verbose overrides Quiet",
+			result: PRResult{
+				RepositoryPRs: []RepositoryPRs{},
+			},
+			config:         &Config{Quiet: true, Output: "verbose"},
+			expectError:    false,
+			expectedFormat: "verbose",
+		},
+		{
+			name: "PRResult - Output=quiet overrides Detailed",
+			result: PRResult{
+				RepositoryPRs: []RepositoryPRs{},
+			},
+			config:         &Config{Detailed: true, Output: "quiet"},
+			expectError:    false,
+			expectedFormat: "quiet",
+		},
 	}
 
 	for _, tt := range tests {
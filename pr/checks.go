@@ -0,0 +1,252 @@
+package pr
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// PRCheck evaluates one match criterion against a PullRequest. Unlike
+// the old Filter struct, a check reports not just whether it matched
+// but why, so callers (List, --explain) can show why a PR was kept or
+// dropped instead of silently filtering it out.
+type PRCheck interface {
+	Name() string
+	Evaluate(pr *PullRequest) (matched bool, detail string)
+}
+
+// CheckResult is the outcome of evaluating one PRCheck against a
+// PullRequest.
+type CheckResult struct {
+	Name    string
+	Matched bool
+	Detail  string
+}
+
+// RunChecks evaluates every check against pr. All checks run, even
+// after one fails, so callers get the full picture rather than the
+// first failure.
+func RunChecks(checks []PRCheck, pr *PullRequest) []CheckResult {
+	results := make([]CheckResult, 0, len(checks))
+	for _, c := range checks {
+		matched, detail := c.Evaluate(pr)
+		results = append(results, CheckResult{Name: c.Name(), Matched: matched, Detail: detail})
+	}
+	return results
+}
+
+// AllMatched reports whether every result matched, i.e. whether the PR
+// the results were computed for should be kept.
+func AllMatched(results []CheckResult) bool {
+	for _, r := range results {
+		if !r.Matched {
+			return false
+		}
+	}
+	return true
+}
+
+// ExplainTable renders results as an aligned "NAME  MATCHED  DETAIL"
+// table, for an --explain-style report of why a PR matched or was
+// filtered out.
+func ExplainTable(results []CheckResult) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tMATCHED\tDETAIL")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Name, yesNo(r.Matched), r.Detail)
+	}
+	w.Flush()
+	return b.String()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// authorCheck matches a PR's author against an exact login, a
+// substring, or both.
+type authorCheck struct {
+	author    string
+	substring string
+}
+
+// NewAuthorCheck returns a PRCheck matching pr.AuthorLogin against
+// author (exact) and/or substring. Either may be empty to skip that
+// criterion.
+func NewAuthorCheck(author, substring string) PRCheck {
+	return &authorCheck{author: author, substring: substring}
+}
+
+func (c *authorCheck) Name() string { return "author" }
+
+func (c *authorCheck) Evaluate(pr *PullRequest) (bool, string) {
+	if c.author != "" && pr.AuthorLogin != c.author {
+		return false, fmt.Sprintf("author %q != %q", pr.AuthorLogin, c.author)
+	}
+	if c.substring != "" && !strings.Contains(pr.AuthorLogin, c.substring) {
+		return false, fmt.Sprintf("author %q does not contain %q", pr.AuthorLogin, c.substring)
+	}
+	return true, fmt.Sprintf("author %q", pr.AuthorLogin)
+}
+
+// labelCheck requires (or forbids, when negate is set) a single label.
+type labelCheck struct {
+	label  string
+	negate bool
+}
+
+// NewLabelCheck returns a PRCheck requiring label, or requiring its
+// absence when negate is true.
+func NewLabelCheck(label string, negate bool) PRCheck {
+	return &labelCheck{label: label, negate: negate}
+}
+
+func (c *labelCheck) Name() string { return "label:" + c.label }
+
+func (c *labelCheck) Evaluate(pr *PullRequest) (bool, string) {
+	has := slices.Contains(pr.Labels, c.label)
+	if c.negate {
+		if has {
+			return false, fmt.Sprintf("has label %q, must not", c.label)
+		}
+		return true, fmt.Sprintf("missing label %q, as required", c.label)
+	}
+	if !has {
+		return false, fmt.Sprintf("missing label %q", c.label)
+	}
+	return true, fmt.Sprintf("has label %q", c.label)
+}
+
+// ciStatusCheck requires a failing CI run when onlyFailing is set.
+type ciStatusCheck struct {
+	onlyFailing bool
+}
+
+// NewCIStatusCheck returns a PRCheck that, when onlyFailing is true,
+// requires the PR to have at least one failing status check.
+func NewCIStatusCheck(onlyFailing bool) PRCheck {
+	return &ciStatusCheck{onlyFailing: onlyFailing}
+}
+
+func (c *ciStatusCheck) Name() string { return "ci-status" }
+
+func (c *ciStatusCheck) Evaluate(pr *PullRequest) (bool, string) {
+	failing := hasFailingCI(*pr)
+	if c.onlyFailing && !failing {
+		return false, "CI is not failing"
+	}
+	if failing {
+		return true, "CI has a failing check"
+	}
+	return true, "CI is not failing"
+}
+
+// stalenessCheck requires the PR's last activity to be at least minAge
+// old.
+type stalenessCheck struct {
+	minAge time.Duration
+}
+
+// NewStalenessCheck returns a PRCheck requiring that pr's last
+// activity (its newest comment, or its creation if it has none) is at
+// least minAge in the past.
+func NewStalenessCheck(minAge time.Duration) PRCheck {
+	return &stalenessCheck{minAge: minAge}
+}
+
+func (c *stalenessCheck) Name() string { return "staleness" }
+
+func (c *stalenessCheck) Evaluate(pr *PullRequest) (bool, string) {
+	last, err := lastActivityAt(pr)
+	if err != nil {
+		return false, fmt.Sprintf("could not determine last activity: %v", err)
+	}
+	age := time.Since(last).Round(time.Minute)
+	if age < c.minAge {
+		return false, fmt.Sprintf("last activity %s ago, younger than %s", age, c.minAge)
+	}
+	return true, fmt.Sprintf("last activity %s ago", age)
+}
+
+// lastActivityAt returns the timestamp of pr's newest comment, or its
+// CreatedAt if it has no comments.
+func lastActivityAt(pr *PullRequest) (time.Time, error) {
+	latest := pr.CreatedAt
+	for _, c := range pr.Comments {
+		if c.CreatedAt > latest {
+			latest = c.CreatedAt
+		}
+	}
+	return time.Parse(time.RFC3339, latest)
+}
+
+// reviewStateCheck requires an exact GitHub review decision, e.g.
+// "APPROVED" or "CHANGES_REQUESTED".
+type reviewStateCheck struct {
+	want string
+}
+
+// NewReviewStateCheck returns a PRCheck requiring pr.ReviewDecision ==
+// want.
+func NewReviewStateCheck(want string) PRCheck {
+	return &reviewStateCheck{want: want}
+}
+
+func (c *reviewStateCheck) Name() string { return "review-state" }
+
+func (c *reviewStateCheck) Evaluate(pr *PullRequest) (bool, string) {
+	if pr.ReviewDecision != c.want {
+		return false, fmt.Sprintf("review decision %q != %q", pr.ReviewDecision, c.want)
+	}
+	return true, fmt.Sprintf("review decision %q", pr.ReviewDecision)
+}
+
+// mergeableCheck requires an exact GitHub mergeable state, e.g.
+// "MERGEABLE" or "CONFLICTING".
+type mergeableCheck struct {
+	want string
+}
+
+// NewMergeableCheck returns a PRCheck requiring pr.Mergeable == want.
+func NewMergeableCheck(want string) PRCheck {
+	return &mergeableCheck{want: want}
+}
+
+func (c *mergeableCheck) Name() string { return "mergeable" }
+
+func (c *mergeableCheck) Evaluate(pr *PullRequest) (bool, string) {
+	if pr.Mergeable != c.want {
+		return false, fmt.Sprintf("mergeable state %q != %q", pr.Mergeable, c.want)
+	}
+	return true, fmt.Sprintf("mergeable state %q", pr.Mergeable)
+}
+
+// filesChangedCheck requires at least one changed file to match a glob
+// pattern (see filepath.Match).
+type filesChangedCheck struct {
+	pattern string
+}
+
+// NewFilesChangedCheck returns a PRCheck requiring at least one of
+// pr.ChangedFiles to match pattern.
+func NewFilesChangedCheck(pattern string) PRCheck {
+	return &filesChangedCheck{pattern: pattern}
+}
+
+func (c *filesChangedCheck) Name() string { return "files-changed" }
+
+func (c *filesChangedCheck) Evaluate(pr *PullRequest) (bool, string) {
+	for _, f := range pr.ChangedFiles {
+		if ok, _ := filepath.Match(c.pattern, f); ok {
+			return true, fmt.Sprintf("changed file %q matches %q", f, c.pattern)
+		}
+	}
+	return false, fmt.Sprintf("no changed file matches %q", c.pattern)
+}
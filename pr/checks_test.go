@@ -0,0 +1,80 @@
+package pr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuthorCheck(t *testing.T) {
+	pr := &PullRequest{AuthorLogin: "alice"}
+
+	if matched, _ := NewAuthorCheck("alice", "").Evaluate(pr); !matched {
+		t.Error("expected exact author match")
+	}
+	if matched, _ := NewAuthorCheck("bob", "").Evaluate(pr); matched {
+		t.Error("expected exact author mismatch to fail")
+	}
+	if matched, _ := NewAuthorCheck("", "lic").Evaluate(pr); !matched {
+		t.Error("expected substring author match")
+	}
+}
+
+func TestLabelCheck(t *testing.T) {
+	pr := &PullRequest{Labels: []string{"lgtm"}}
+
+	if matched, _ := NewLabelCheck("lgtm", false).Evaluate(pr); !matched {
+		t.Error("expected label to match")
+	}
+	if matched, _ := NewLabelCheck("approved", false).Evaluate(pr); matched {
+		t.Error("expected missing label to fail")
+	}
+	if matched, _ := NewLabelCheck("lgtm", true).Evaluate(pr); matched {
+		t.Error("expected negated label match to fail")
+	}
+	if matched, _ := NewLabelCheck("approved", true).Evaluate(pr); !matched {
+		t.Error("expected negated missing label to match")
+	}
+}
+
+func TestStalenessCheck(t *testing.T) {
+	pr := &PullRequest{CreatedAt: time.Now().Add(-2 * time.Hour).Format(time.RFC3339)}
+
+	if matched, _ := NewStalenessCheck(time.Hour).Evaluate(pr); !matched {
+		t.Error("expected PR older than threshold to match")
+	}
+	if matched, _ := NewStalenessCheck(24 * time.Hour).Evaluate(pr); matched {
+		t.Error("expected PR younger than threshold not to match")
+	}
+}
+
+func TestFilesChangedCheck(t *testing.T) {
+	pr := &PullRequest{ChangedFiles: []string{"pr/checks.go", "README.md"}}
+
+	if matched, _ := NewFilesChangedCheck("pr/*.go").Evaluate(pr); !matched {
+		t.Error("expected glob to match a changed file")
+	}
+	if matched, _ := NewFilesChangedCheck("*.yaml").Evaluate(pr); matched {
+		t.Error("expected glob to not match any changed file")
+	}
+}
+
+func TestRunChecksAndAllMatched(t *testing.T) {
+	pr := &PullRequest{AuthorLogin: "alice", Labels: []string{"lgtm"}}
+	checks := []PRCheck{
+		NewAuthorCheck("alice", ""),
+		NewLabelCheck("lgtm", false),
+	}
+
+	results := RunChecks(checks, pr)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if !AllMatched(results) {
+		t.Error("expected all checks to match")
+	}
+
+	checks = append(checks, NewLabelCheck("approved", false))
+	if AllMatched(RunChecks(checks, pr)) {
+		t.Error("expected AllMatched to be false once a check fails")
+	}
+}
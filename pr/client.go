@@ -1,20 +1,37 @@
 package pr
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 )
 
-type Client struct {
-	repo string
+// githubClient is the RepoClient implementation backed by GitHub's
+// GraphQL and REST APIs via gh(1).
+type githubClient struct {
+	owner, name string
 }
 
-func NewClient(repo string) (*Client, error) {
-	return &Client{repo: repo}, nil
+func newGitHubClient(owner, name string) *githubClient {
+	return &githubClient{owner: owner, name: name}
 }
 
-func (c *Client) List(filter Filter) ([]PullRequest, error) {
-	prs, err := fetchPullRequests(c.repo, false)
+func (c *githubClient) repo() string {
+	return c.owner + "/" + c.name
+}
+
+// NewClient returns a RepoClient for repo ("owner/name") backed by
+// GitHub. For other forges, use NewRepoClient.
+func NewClient(repo string) (RepoClient, error) {
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repo: %q", repo)
+	}
+	return newGitHubClient(parts[0], parts[1]), nil
+}
+
+func (c *githubClient) List(checks []PRCheck) ([]PullRequest, error) {
+	prs, err := fetchPullRequests(c.repo(), false)
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +39,7 @@ func (c *Client) List(filter Filter) ([]PullRequest, error) {
 	filtered := make([]PullRequest, 0, len(prs))
 
 	for _, pr := range prs {
-		if matchesFilter(pr, filter) {
+		if AllMatched(RunChecks(checks, &pr)) {
 			filtered = append(filtered, pr)
 		}
 	}
@@ -32,37 +49,6 @@ func (c *Client) List(filter Filter) ([]PullRequest, error) {
 	return filtered, nil
 }
 
-func matchesFilter(pr PullRequest, filter Filter) bool {
-	// Author exact match
-	if filter.Author != "" && pr.AuthorLogin != filter.Author {
-		return false
-	}
-
-	// Author substring match
-	if filter.AuthorSubstring != "" && !strings.Contains(pr.AuthorLogin, filter.AuthorSubstring) {
-		return false
-	}
-
-	// Label filters
-	for _, labelFilter := range filter.Labels {
-		hasLabel := slices.Contains(pr.Labels, labelFilter.Name)
-		if labelFilter.Negate && hasLabel {
-			// Should NOT have the label but does.
-			return false
-		}
-		if !labelFilter.Negate && !hasLabel {
-			// Should have the label but doesn't.
-			return false
-		}
-	}
-
-	if filter.OnlyFailingCI && !hasFailingCI(pr) {
-		return false
-	}
-
-	return true
-}
-
 func hasFailingCI(pr PullRequest) bool {
 	for _, check := range pr.StatusCheckRollup.Contexts.Nodes {
 		if check.State == "FAILURE" || check.Conclusion == "FAILURE" {
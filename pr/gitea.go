@@ -0,0 +1,158 @@
+package pr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// giteaClient is the RepoClient implementation backed by the Gitea (and
+// Forgejo/Codeberg-compatible) REST API, authenticated via the
+// GITEA_TOKEN environment variable.
+type giteaClient struct {
+	host, owner, name string
+	httpClient        *http.Client
+}
+
+func newGiteaClient(host, owner, name string) *giteaClient {
+	return &giteaClient{host: host, owner: owner, name: name, httpClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (c *giteaClient) apiURL(path string) string {
+	return fmt.Sprintf("https://%s/api/v1/repos/%s/%s%s", c.host, c.owner, c.name, path)
+}
+
+func (c *giteaClient) do(method, path string, body []byte, out any) error {
+	req, err := http.NewRequest(method, path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if token := os.Getenv("GITEA_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("Gitea API %s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type giteaPullRequest struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	CreatedAt string `json:"created_at"`
+	State     string `json:"state"`
+	HTMLURL   string `json:"html_url"`
+	Head      struct {
+		Ref string `json:"ref"`
+		Sha string `json:"sha"`
+	} `json:"head"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+func (c *giteaClient) List(checks []PRCheck) ([]PullRequest, error) {
+	var pulls []giteaPullRequest
+	if err := c.do(http.MethodGet, c.apiURL("/pulls?state=open&limit=100"), nil, &pulls); err != nil {
+		return nil, err
+	}
+
+	prs := make([]PullRequest, 0, len(pulls))
+	for _, p := range pulls {
+		labelNames := make([]string, 0, len(p.Labels))
+		for _, l := range p.Labels {
+			labelNames = append(labelNames, l.Name)
+		}
+
+		pr := PullRequest{
+			Number:      p.Number,
+			Title:       p.Title,
+			HeadRefName: p.Head.Ref,
+			CreatedAt:   p.CreatedAt,
+			State:       strings.ToUpper(p.State),
+			Labels:      labelNames,
+			AuthorLogin: p.User.Login,
+			URL:         p.HTMLURL,
+			repo:        c.owner + "/" + c.name,
+		}
+		if AllMatched(RunChecks(checks, &pr)) {
+			prs = append(prs, pr)
+		}
+	}
+
+	sortPRsDescending(prs)
+
+	return prs, nil
+}
+
+// Comment posts body as an issue comment on pull request number.
+func (c *giteaClient) Comment(number int, body string) error {
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	return c.do(http.MethodPost, c.apiURL("/issues/"+strconv.Itoa(number)+"/comments"), payload, nil)
+}
+
+type giteaCommitStatus struct {
+	Context   string `json:"context"`
+	Status    string `json:"status"`
+	TargetURL string `json:"target_url"`
+}
+
+// FetchChecks returns the commit statuses Gitea reports for pull
+// request number's head commit.
+func (c *giteaClient) FetchChecks(number int) ([]StatusCheck, error) {
+	var p giteaPullRequest
+	if err := c.do(http.MethodGet, c.apiURL("/pulls/"+strconv.Itoa(number)), nil, &p); err != nil {
+		return nil, err
+	}
+	if p.Head.Sha == "" {
+		return nil, fmt.Errorf("pull request #%d has no head commit", number)
+	}
+
+	var statuses []giteaCommitStatus
+	if err := c.do(http.MethodGet, c.apiURL("/commits/"+p.Head.Sha+"/statuses"), nil, &statuses); err != nil {
+		return nil, err
+	}
+
+	checks := make([]StatusCheck, 0, len(statuses))
+	for _, s := range statuses {
+		checks = append(checks, StatusCheck{
+			Context:    s.Context,
+			State:      strings.ToUpper(s.Status),
+			Conclusion: strings.ToUpper(s.Status),
+			TargetUrl:  s.TargetURL,
+		})
+	}
+
+	return checks, nil
+}
+
+// FetchCheckLogs is not yet implemented for Gitea: commit statuses only
+// link to an external CI's target_url, which has no common log format
+// to parse across providers.
+func (c *giteaClient) FetchCheckLogs(check StatusCheck) (string, error) {
+	return "", fmt.Errorf("FetchCheckLogs is not yet implemented for Gitea")
+}
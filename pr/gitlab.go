@@ -0,0 +1,143 @@
+package pr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gitlabClient is the RepoClient implementation backed by GitLab's REST
+// (v4) API, authenticated via the GITLAB_TOKEN environment variable.
+type gitlabClient struct {
+	host, owner, name string
+	httpClient        *http.Client
+}
+
+func newGitLabClient(host, owner, name string) *gitlabClient {
+	return &gitlabClient{host: host, owner: owner, name: name, httpClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (c *gitlabClient) projectID() string {
+	return url.PathEscape(c.owner + "/" + c.name)
+}
+
+func (c *gitlabClient) apiURL(path string) string {
+	return fmt.Sprintf("https://%s/api/v4/projects/%s%s", c.host, c.projectID(), path)
+}
+
+func (c *gitlabClient) do(method, path string, body []byte, out any) error {
+	req, err := http.NewRequest(method, path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	}
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("GitLab API %s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type gitlabMergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	SourceBranch string `json:"source_branch"`
+	CreatedAt    string `json:"created_at"`
+	State        string `json:"state"`
+	WebURL       string `json:"web_url"`
+	Author       struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Labels []string `json:"labels"`
+}
+
+func (c *gitlabClient) List(checks []PRCheck) ([]PullRequest, error) {
+	var mrs []gitlabMergeRequest
+	if err := c.do(http.MethodGet, c.apiURL("/merge_requests?state=opened&per_page=100"), nil, &mrs); err != nil {
+		return nil, err
+	}
+
+	prs := make([]PullRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		pr := PullRequest{
+			Number:      mr.IID,
+			Title:       mr.Title,
+			HeadRefName: mr.SourceBranch,
+			CreatedAt:   mr.CreatedAt,
+			State:       strings.ToUpper(mr.State),
+			Labels:      mr.Labels,
+			AuthorLogin: mr.Author.Username,
+			URL:         mr.WebURL,
+			repo:        c.owner + "/" + c.name,
+		}
+		if AllMatched(RunChecks(checks, &pr)) {
+			prs = append(prs, pr)
+		}
+	}
+
+	sortPRsDescending(prs)
+
+	return prs, nil
+}
+
+// Comment posts body as a note on merge request number.
+func (c *gitlabClient) Comment(number int, body string) error {
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+	return c.do(http.MethodPost, c.apiURL("/merge_requests/"+strconv.Itoa(number)+"/notes"), payload, nil)
+}
+
+type gitlabPipeline struct {
+	Status string `json:"status"`
+	WebURL string `json:"web_url"`
+}
+
+// FetchChecks returns the most recent pipeline's status as a single
+// StatusCheck. GitLab surfaces CI as pipelines of jobs rather than
+// GitHub-style named checks, so this collapses the pipeline to one
+// check rather than trying to map it onto GitHub's per-job shape.
+func (c *gitlabClient) FetchChecks(number int) ([]StatusCheck, error) {
+	var pipelines []gitlabPipeline
+	if err := c.do(http.MethodGet, c.apiURL("/merge_requests/"+strconv.Itoa(number)+"/pipelines"), nil, &pipelines); err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return nil, nil
+	}
+
+	latest := pipelines[0]
+	return []StatusCheck{{
+		Context:    "pipeline",
+		State:      strings.ToUpper(latest.Status),
+		Conclusion: strings.ToUpper(latest.Status),
+		TargetUrl:  latest.WebURL,
+	}}, nil
+}
+
+// FetchCheckLogs is not yet implemented for GitLab: fetching a trace
+// requires a separate per-job lookup that this client doesn't do yet.
+func (c *gitlabClient) FetchCheckLogs(check StatusCheck) (string, error) {
+	return "", fmt.Errorf("FetchCheckLogs is not yet implemented for GitLab")
+}
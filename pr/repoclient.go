@@ -0,0 +1,79 @@
+package pr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RepoClient is a forge-agnostic interface for listing, commenting on,
+// and inspecting CI for pull/merge requests. githubClient, gitlabClient,
+// and giteaClient each adapt one forge's API to this shape so callers
+// don't need to know which forge a given repo lives on.
+type RepoClient interface {
+	List(checks []PRCheck) ([]PullRequest, error)
+	Comment(number int, body string) error
+	FetchChecks(number int) ([]StatusCheck, error)
+	FetchCheckLogs(check StatusCheck) (string, error)
+}
+
+// knownHosts maps a forge's host to the constructor for its RepoClient,
+// used when NewRepoClient isn't given an explicit forge.
+var knownHosts = map[string]func(owner, name string) (RepoClient, error){
+	"github.com": func(owner, name string) (RepoClient, error) {
+		return newGitHubClient(owner, name), nil
+	},
+	"gitlab.com": func(owner, name string) (RepoClient, error) {
+		return newGitLabClient("gitlab.com", owner, name), nil
+	},
+	"codeberg.org": func(owner, name string) (RepoClient, error) {
+		return newGiteaClient("codeberg.org", owner, name), nil
+	},
+}
+
+// NewRepoClient builds the RepoClient for repo, selecting an
+// implementation by forge. repo is either "owner/name" (assumed to be
+// github.com, matching the historical behaviour of NewClient) or
+// "host/owner/name" for a non-GitHub forge, e.g.
+// "gitlab.example.com/owner/name". An explicit forge ("github",
+// "gitlab", or "gitea") overrides host-based detection, which is
+// required for self-hosted instances that don't appear in knownHosts.
+func NewRepoClient(repo, forge string) (RepoClient, error) {
+	host, owner, name, err := splitRepoRef(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if forge != "" {
+		switch forge {
+		case "github":
+			return newGitHubClient(owner, name), nil
+		case "gitlab":
+			return newGitLabClient(host, owner, name), nil
+		case "gitea":
+			return newGiteaClient(host, owner, name), nil
+		default:
+			return nil, fmt.Errorf("unknown forge %q, expected github, gitlab, or gitea", forge)
+		}
+	}
+
+	if ctor, ok := knownHosts[host]; ok {
+		return ctor(owner, name)
+	}
+
+	return nil, fmt.Errorf("unrecognised forge host %q for %q; pass an explicit forge to select one", host, repo)
+}
+
+// splitRepoRef splits repo into a host, owner, and name. "owner/name"
+// is assumed to be github.com, matching the historical behaviour of
+// NewClient; "host/owner/name" names a specific forge instance.
+func splitRepoRef(repo string) (host, owner, name string, err error) {
+	parts := strings.Split(repo, "/")
+	switch len(parts) {
+	case 2:
+		return "github.com", parts[0], parts[1], nil
+	case 3:
+		return parts[0], parts[1], parts[2], nil
+	default:
+		return "", "", "", fmt.Errorf("invalid repo: %q", repo)
+	}
+}
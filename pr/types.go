@@ -13,7 +13,17 @@ type PullRequest struct {
 	State             string
 	StatusCheckRollup StatusCheckRollup
 	Comments          []Comment
-	repo              string
+	// ReviewDecision is GitHub's aggregate review state, e.g.
+	// "APPROVED", "CHANGES_REQUESTED", or "REVIEW_REQUIRED". Used by
+	// reviewStateCheck.
+	ReviewDecision string
+	// Mergeable is GitHub's mergeable state, e.g. "MERGEABLE",
+	// "CONFLICTING", or "UNKNOWN". Used by mergeableCheck.
+	Mergeable string
+	// ChangedFiles lists the paths this PR touches. Used by
+	// filesChangedCheck.
+	ChangedFiles []string
+	repo         string
 }
 
 type StatusCheckRollup struct {
@@ -38,17 +48,3 @@ type Comment struct {
 		Login string `json:"login"`
 	} `json:"author"`
 }
-
-type LabelFilter struct {
-	Name   string
-	Negate bool
-}
-
-// Filter expresses optional match criteria for PR selection.
-type Filter struct {
-	Author          string
-	AuthorSubstring string
-	Labels          []LabelFilter
-	OnlyFailingCI   bool
-	FailingChecks   []string
-}
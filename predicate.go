@@ -0,0 +1,410 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frobware/autoprat/github/depbump"
+)
+
+// PredicateExpr is a compiled `when:`/`skip_when:` expression that can
+// be evaluated against a pull request.
+type PredicateExpr interface {
+	Evaluate(pr PullRequest) bool
+}
+
+// predicateFunc adapts a plain func(PullRequest) bool to the
+// PredicateExpr interface.
+type predicateFunc func(pr PullRequest) bool
+
+func (f predicateFunc) Evaluate(pr PullRequest) bool {
+	return f(pr)
+}
+
+type notExpr struct{ inner PredicateExpr }
+
+func (n notExpr) Evaluate(pr PullRequest) bool { return !n.inner.Evaluate(pr) }
+
+type andExpr struct{ left, right PredicateExpr }
+
+func (a andExpr) Evaluate(pr PullRequest) bool { return a.left.Evaluate(pr) && a.right.Evaluate(pr) }
+
+type orExpr struct{ left, right PredicateExpr }
+
+func (o orExpr) Evaluate(pr PullRequest) bool { return o.left.Evaluate(pr) || o.right.Evaluate(pr) }
+
+// hasExpr implements has(<glob>) against pr.Labels using shell-style
+// glob matching (so "do-not-merge/*" matches "do-not-merge/hold").
+type hasExpr struct{ pattern string }
+
+func (h hasExpr) Evaluate(pr PullRequest) bool {
+	for _, label := range pr.Labels {
+		if ok, _ := filepath.Match(h.pattern, label); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// equalsExpr implements `field == "value"` for the supported fields:
+// author, state, ci. author's value may be a shell-style glob (e.g.
+// "dependabot*"), matched the same way has() matches labels; a literal
+// value with no glob characters behaves as plain equality.
+type equalsExpr struct {
+	field string
+	value string
+}
+
+func (e equalsExpr) Evaluate(pr PullRequest) bool {
+	switch e.field {
+	case "author":
+		ok, _ := filepath.Match(e.value, pr.Author())
+		return ok
+	case "state":
+		return pr.State == e.value
+	case "ci":
+		return pr.CIStatus() == e.value
+	default:
+		return false
+	}
+}
+
+// ageExpr implements age(">24h") computed from pr.CreatedAt.
+type ageExpr struct {
+	op  string
+	dur time.Duration
+}
+
+func (a ageExpr) Evaluate(pr PullRequest) bool {
+	createdAt, err := time.Parse(time.RFC3339, pr.CreatedAt)
+	if err != nil {
+		return false
+	}
+	return compareDuration(a.op, time.Since(createdAt), a.dur)
+}
+
+// staleExpr implements stale(">2h"), computed from how long it's been
+// since the last comment. PRs with no comments yet fall back to
+// pr.CreatedAt, so a never-commented-on PR is "stale" once it's old
+// enough rather than never matching.
+type staleExpr struct {
+	op  string
+	dur time.Duration
+}
+
+func (s staleExpr) Evaluate(pr PullRequest) bool {
+	since, ok := lastCommentAt(pr)
+	if !ok {
+		createdAt, err := time.Parse(time.RFC3339, pr.CreatedAt)
+		if err != nil {
+			return false
+		}
+		since = createdAt
+	}
+	return compareDuration(s.op, time.Since(since), s.dur)
+}
+
+// branchExpr implements branch("regex") against pr.HeadRefName.
+type branchExpr struct{ re *regexp.Regexp }
+
+func (b branchExpr) Evaluate(pr PullRequest) bool {
+	return b.re.MatchString(pr.HeadRefName)
+}
+
+func compareDuration(op string, elapsed, threshold time.Duration) bool {
+	switch op {
+	case ">":
+		return elapsed > threshold
+	case ">=":
+		return elapsed >= threshold
+	case "<":
+		return elapsed < threshold
+	case "<=":
+		return elapsed <= threshold
+	default:
+		return false
+	}
+}
+
+// versionBumpExpr implements version_bump("patch") against a title/
+// branch classified by depbump, for gating auto-merge actions on
+// dependency-update PRs (e.g. "approve patch bumps only").
+type versionBumpExpr struct {
+	registry *depbump.Registry
+	level    depbump.Bump
+}
+
+func (v versionBumpExpr) Evaluate(pr PullRequest) bool {
+	bump, ok := v.registry.Classify(pr.Title, pr.HeadRefName)
+	return ok && bump == v.level
+}
+
+// CompilePredicate parses a `when:`/`skip_when:` expression into a
+// PredicateExpr. Grammar:
+//
+//	expr   := or
+//	or     := and ( "||" and )*
+//	and    := unary ( "&&" unary )*
+//	unary  := "!" unary | atom
+//	atom   := "(" expr ")" | call | field "==" string
+//	call   := "has" "(" string ")" | "age" "(" string ")" | "stale" "(" string ")"
+//	        | "branch" "(" string ")" | "version_bump" "(" string ")"
+func CompilePredicate(src string) (PredicateExpr, error) {
+	p := &predicateParser{tokens: tokenisePredicate(src), src: src}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in predicate %q", p.tokens[p.pos], src)
+	}
+	return expr, nil
+}
+
+type predicateParser struct {
+	tokens []string
+	pos    int
+	src    string
+}
+
+func (p *predicateParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *predicateParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *predicateParser) parseOr() (PredicateExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *predicateParser) parseAnd() (PredicateExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *predicateParser) parseUnary() (PredicateExpr, error) {
+	if p.peek() == "!" {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner}, nil
+	}
+	return p.parseAtom()
+}
+
+func (p *predicateParser) parseAtom() (PredicateExpr, error) {
+	tok := p.next()
+	switch {
+	case tok == "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing closing paren in predicate %q", p.src)
+		}
+		return expr, nil
+	case tok == "has":
+		return p.parseCall(func(arg string) PredicateExpr { return hasExpr{pattern: arg} })
+	case tok == "age":
+		return p.parseDurationCall(func(op string, dur time.Duration) PredicateExpr { return ageExpr{op: op, dur: dur} })
+	case tok == "stale":
+		return p.parseDurationCall(func(op string, dur time.Duration) PredicateExpr { return staleExpr{op: op, dur: dur} })
+	case tok == "branch":
+		return p.parseBranchCall()
+	case tok == "version_bump":
+		return p.parseVersionBumpCall()
+	case tok == "author" || tok == "state" || tok == "ci":
+		if p.next() != "==" {
+			return nil, fmt.Errorf("expected == after %q in predicate %q", tok, p.src)
+		}
+		value, err := unquote(p.next())
+		if err != nil {
+			return nil, err
+		}
+		return equalsExpr{field: tok, value: value}, nil
+	default:
+		return nil, fmt.Errorf("unexpected token %q in predicate %q", tok, p.src)
+	}
+}
+
+func (p *predicateParser) parseCall(build func(string) PredicateExpr) (PredicateExpr, error) {
+	if p.next() != "(" {
+		return nil, fmt.Errorf("expected ( in predicate %q", p.src)
+	}
+	arg, err := unquote(p.next())
+	if err != nil {
+		return nil, err
+	}
+	if p.next() != ")" {
+		return nil, fmt.Errorf("expected ) in predicate %q", p.src)
+	}
+	return build(arg), nil
+}
+
+// parseDurationCall parses the common "(op)(duration)" argument shared
+// by age() and stale(), e.g. age(">24h") or stale("<=2h"), and hands
+// the parsed operator/duration to build.
+func (p *predicateParser) parseDurationCall(build func(op string, dur time.Duration) PredicateExpr) (PredicateExpr, error) {
+	if p.next() != "(" {
+		return nil, fmt.Errorf("expected ( in predicate %q", p.src)
+	}
+	arg, err := unquote(p.next())
+	if err != nil {
+		return nil, err
+	}
+	if p.next() != ")" {
+		return nil, fmt.Errorf("expected ) in predicate %q", p.src)
+	}
+
+	op, durStr := splitAgeOperator(arg)
+	if op == "" {
+		return nil, fmt.Errorf("invalid duration comparison %q, must start with >, >=, < or <=", arg)
+	}
+	dur, err := time.ParseDuration(durStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration in %q: %w", arg, err)
+	}
+	return build(op, dur), nil
+}
+
+func (p *predicateParser) parseBranchCall() (PredicateExpr, error) {
+	if p.next() != "(" {
+		return nil, fmt.Errorf("expected ( in predicate %q", p.src)
+	}
+	arg, err := unquote(p.next())
+	if err != nil {
+		return nil, err
+	}
+	if p.next() != ")" {
+		return nil, fmt.Errorf("expected ) in predicate %q", p.src)
+	}
+
+	re, err := regexp.Compile(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex in branch(%q): %w", arg, err)
+	}
+	return branchExpr{re: re}, nil
+}
+
+func (p *predicateParser) parseVersionBumpCall() (PredicateExpr, error) {
+	if p.next() != "(" {
+		return nil, fmt.Errorf("expected ( in predicate %q", p.src)
+	}
+	arg, err := unquote(p.next())
+	if err != nil {
+		return nil, err
+	}
+	if p.next() != ")" {
+		return nil, fmt.Errorf("expected ) in predicate %q", p.src)
+	}
+
+	level, ok := depbump.ParseBump(arg)
+	if !ok {
+		return nil, fmt.Errorf("invalid bump level %q in version_bump(...), must be patch, minor or major", arg)
+	}
+
+	registry, err := depbump.NewRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load depbump rules: %w", err)
+	}
+
+	return versionBumpExpr{registry: registry, level: level}, nil
+}
+
+func splitAgeOperator(s string) (op, rest string) {
+	for _, candidate := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(s, candidate) {
+			return candidate, strings.TrimSpace(strings.TrimPrefix(s, candidate))
+		}
+	}
+	return "", s
+}
+
+func unquote(tok string) (string, error) {
+	if len(tok) < 2 || tok[0] != '"' || tok[len(tok)-1] != '"' {
+		return "", fmt.Errorf("expected quoted string, got %q", tok)
+	}
+	return strconv.Unquote(tok)
+}
+
+// tokenisePredicate splits a predicate expression into tokens:
+// identifiers, quoted strings, and the operators &&, ||, !, ==, (, ).
+func tokenisePredicate(src string) []string {
+	var tokens []string
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t':
+			continue
+		case r == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j+1]))
+			i = j
+		case strings.HasPrefix(string(runes[i:]), "&&"):
+			tokens = append(tokens, "&&")
+			i++
+		case strings.HasPrefix(string(runes[i:]), "||"):
+			tokens = append(tokens, "||")
+			i++
+		case strings.HasPrefix(string(runes[i:]), "=="):
+			tokens = append(tokens, "==")
+			i++
+		case r == '!' || r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+		default:
+			j := i
+			for j < len(runes) && runes[j] != ' ' && runes[j] != '(' && runes[j] != ')' {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j - 1
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frobware/autoprat/github/depbump"
+)
+
+func TestCompilePredicate(t *testing.T) {
+	oldCreatedAt := time.Now().Add(-48 * time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		expr    string
+		pr      PullRequest
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "has glob match",
+			expr: `has("do-not-merge/*")`,
+			pr:   PullRequest{Labels: []string{"do-not-merge/hold"}},
+			want: true,
+		},
+		{
+			name: "has glob no match",
+			expr: `has("do-not-merge/*")`,
+			pr:   PullRequest{Labels: []string{"lgtm"}},
+			want: false,
+		},
+		{
+			name: "negation",
+			expr: `!has("hold")`,
+			pr:   PullRequest{Labels: []string{"lgtm"}},
+			want: true,
+		},
+		{
+			name: "and/or composition",
+			expr: `has("lgtm") && !has("hold")`,
+			pr:   PullRequest{Labels: []string{"lgtm"}},
+			want: true,
+		},
+		{
+			name: "author equality",
+			expr: `author == "app/dependabot"`,
+			pr:   PullRequest{AuthorLogin: "dependabot", AuthorType: "Bot"},
+			want: true,
+		},
+		{
+			name: "ci equality",
+			expr: `ci == "Passing"`,
+			pr:   PullRequest{},
+			want: true,
+		},
+		{
+			name: "age comparison",
+			expr: `age(">24h")`,
+			pr:   PullRequest{CreatedAt: oldCreatedAt},
+			want: true,
+		},
+		{
+			name: "author glob match",
+			expr: `author == "dependabot*"`,
+			pr:   PullRequest{AuthorLogin: "dependabot-preview"},
+			want: true,
+		},
+		{
+			name: "author glob no match",
+			expr: `author == "dependabot*"`,
+			pr:   PullRequest{AuthorLogin: "octocat"},
+			want: false,
+		},
+		{
+			name: "stale comparison falls back to CreatedAt when there are no comments",
+			expr: `stale(">24h")`,
+			pr:   PullRequest{CreatedAt: oldCreatedAt},
+			want: true,
+		},
+		{
+			name: "stale comparison uses the last comment time when present",
+			expr: `stale(">24h")`,
+			pr: PullRequest{
+				CreatedAt: oldCreatedAt,
+				Comments:  []Comment{{CreatedAt: time.Now().Format(time.RFC3339)}},
+			},
+			want: false,
+		},
+		{
+			name: "branch regex match",
+			expr: `branch("^release-.*")`,
+			pr:   PullRequest{HeadRefName: "release-1.2"},
+			want: true,
+		},
+		{
+			name: "branch regex no match",
+			expr: `branch("^release-.*")`,
+			pr:   PullRequest{HeadRefName: "main"},
+			want: false,
+		},
+		{
+			name:    "invalid expression",
+			expr:    `has(`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid branch regex",
+			expr:    `branch("(")`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := CompilePredicate(tt.expr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CompilePredicate(%q) expected error, got nil", tt.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompilePredicate(%q) failed: %v", tt.expr, err)
+			}
+
+			if got := expr.Evaluate(tt.pr); got != tt.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompilePredicate_VersionBumpInvalidLevel(t *testing.T) {
+	if _, err := CompilePredicate(`version_bump("bogus")`); err == nil {
+		t.Fatal("expected an error for an unrecognised bump level")
+	}
+}
+
+func TestVersionBumpExpr_Evaluate(t *testing.T) {
+	registry, err := depbump.NewRegistryFromDocuments(`
+name: dependabot
+title_pattern: '(?i)^chore\(deps\): bump \S+ from (?P<from>[0-9][\w.+-]*) to (?P<to>[0-9][\w.+-]*)'
+`)
+	if err != nil {
+		t.Fatalf("NewRegistryFromDocuments failed: %v", err)
+	}
+
+	patch := versionBumpExpr{registry: registry, level: depbump.BumpPatch}
+	pr := PullRequest{Title: "chore(deps): bump lodash from 4.17.20 to 4.17.21"}
+	if !patch.Evaluate(pr) {
+		t.Error("expected a patch bump to match version_bump(\"patch\")")
+	}
+
+	major := versionBumpExpr{registry: registry, level: depbump.BumpMajor}
+	if major.Evaluate(pr) {
+		t.Error("expected a patch bump not to match version_bump(\"major\")")
+	}
+}
+
+func TestFilterActionsWithPredicates(t *testing.T) {
+	whenExpr, err := CompilePredicate(`has("lgtm") && !has("do-not-merge/*")`)
+	if err != nil {
+		t.Fatalf("CompilePredicate failed: %v", err)
+	}
+
+	actions := []Action{
+		{Comment: "/approve", When: whenExpr},
+	}
+
+	matching := PullRequest{Labels: []string{"lgtm"}}
+	if got := FilterActions(actions, matching); len(got) != 1 {
+		t.Errorf("expected 1 action for matching PR, got %d", len(got))
+	}
+
+	blocked := PullRequest{Labels: []string{"lgtm", "do-not-merge/hold"}}
+	if got := FilterActions(actions, blocked); len(got) != 0 {
+		t.Errorf("expected 0 actions for blocked PR, got %d", len(got))
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a named, composable bundle of default flags loaded from
+// the user's "~/.config/autoprat/config.yaml", letting users collapse
+// a long-winded invocation like "--repo a --repo b --needs-lgtm
+// --not-draft --detailed" into "--profile my-team-review-queue".
+// Profiles compose via Inherits: a profile's own fields override
+// whatever its parent sets, and Repositories/Filters/Actions append.
+type Profile struct {
+	Inherits     string   `yaml:"inherits,omitempty"`
+	Repositories []string `yaml:"repositories,omitempty"`
+	Filters      []string `yaml:"filters,omitempty"`
+	Actions      []string `yaml:"actions,omitempty"`
+	Filter       string   `yaml:"filter,omitempty"`
+	Output       string   `yaml:"output,omitempty"`
+	Detailed     bool     `yaml:"detailed,omitempty"`
+	Quiet        bool     `yaml:"quiet,omitempty"`
+}
+
+// ProfilesFile is the top-level shape of the user's config.yaml.
+type ProfilesFile struct {
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// defaultProfilesConfigFile returns where --profile loads named
+// profiles from: $XDG_CONFIG_HOME/autoprat/config.yaml, or
+// $HOME/.config/autoprat/config.yaml if unset.
+func defaultProfilesConfigFile() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "autoprat", "config.yaml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autoprat", "config.yaml")
+}
+
+// loadProfiles reads and parses the user's profiles file. Returns a
+// nil map and ok=false if no profiles file is found.
+func loadProfiles() (map[string]Profile, bool, error) {
+	path := defaultProfilesConfigFile()
+	if path == "" {
+		return nil, false, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	var doc ProfilesFile
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return doc.Profiles, true, nil
+}
+
+// resolveProfile flattens name's Inherits chain into a single Profile,
+// with each profile's own fields overriding whatever it inherits and
+// Repositories/Filters/Actions accumulating from base to derived. It
+// returns an error for an unknown profile name or a circular Inherits
+// chain.
+func resolveProfile(profiles map[string]Profile, name string) (Profile, error) {
+	var chain []Profile
+	seen := map[string]bool{}
+
+	for cur := name; ; {
+		if seen[cur] {
+			return Profile{}, fmt.Errorf("circular profile inheritance involving %q", cur)
+		}
+		seen[cur] = true
+
+		p, ok := profiles[cur]
+		if !ok {
+			return Profile{}, fmt.Errorf("unknown profile %q", cur)
+		}
+		chain = append(chain, p)
+
+		if p.Inherits == "" {
+			break
+		}
+		cur = p.Inherits
+	}
+
+	var merged Profile
+	for i := len(chain) - 1; i >= 0; i-- {
+		merged = mergeProfiles(merged, chain[i])
+	}
+	return merged, nil
+}
+
+// mergeProfiles overlays override onto base: scalar fields win if set,
+// slice fields accumulate.
+func mergeProfiles(base, override Profile) Profile {
+	merged := base
+	merged.Repositories = append(append([]string{}, base.Repositories...), override.Repositories...)
+	merged.Filters = append(append([]string{}, base.Filters...), override.Filters...)
+	merged.Actions = append(append([]string{}, base.Actions...), override.Actions...)
+	if override.Filter != "" {
+		merged.Filter = override.Filter
+	}
+	if override.Output != "" {
+		merged.Output = override.Output
+	}
+	if override.Detailed {
+		merged.Detailed = true
+	}
+	if override.Quiet {
+		merged.Quiet = true
+	}
+	return merged
+}
+
+// ListProfileNames returns profiles' names in sorted order, for
+// --list-profiles.
+func ListProfileNames(profiles map[string]Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadProfiles(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte(`
+profiles:
+  my-team:
+    repositories:
+      - owner/a
+    filters:
+      - needs-lgtm
+    detailed: true
+`)
+	configDir := filepath.Join(dir, "autoprat")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	profiles, ok, err := loadProfiles()
+	if err != nil {
+		t.Fatalf("loadProfiles failed: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a profiles file to be found")
+	}
+	p, exists := profiles["my-team"]
+	if !exists {
+		t.Fatal("expected profile \"my-team\" to exist")
+	}
+	if len(p.Repositories) != 1 || p.Repositories[0] != "owner/a" {
+		t.Errorf("Repositories = %v, want [owner/a]", p.Repositories)
+	}
+	if !p.Detailed {
+		t.Error("Detailed = false, want true")
+	}
+}
+
+func TestLoadProfiles_NoConfigFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	_, ok, err := loadProfiles()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected no profiles file to be found in an empty temp dir")
+	}
+}
+
+func TestResolveProfile(t *testing.T) {
+	profiles := map[string]Profile{
+		"base": {
+			Repositories: []string{"owner/base"},
+			Detailed:     true,
+		},
+		"child": {
+			Inherits:     "base",
+			Repositories: []string{"owner/child"},
+			Quiet:        true,
+		},
+	}
+
+	resolved, err := resolveProfile(profiles, "child")
+	if err != nil {
+		t.Fatalf("resolveProfile failed: %v", err)
+	}
+	if len(resolved.Repositories) != 2 || resolved.Repositories[0] != "owner/base" || resolved.Repositories[1] != "owner/child" {
+		t.Errorf("Repositories = %v, want [owner/base owner/child]", resolved.Repositories)
+	}
+	if !resolved.Detailed {
+		t.Error("Detailed = false, want true (inherited from base)")
+	}
+	if !resolved.Quiet {
+		t.Error("Quiet = false, want true")
+	}
+}
+
+func TestResolveProfile_UnknownName(t *testing.T) {
+	if _, err := resolveProfile(map[string]Profile{}, "missing"); err == nil {
+		t.Fatal("expected an error for an unknown profile name")
+	}
+}
+
+func TestResolveProfile_CircularInheritance(t *testing.T) {
+	profiles := map[string]Profile{
+		"a": {Inherits: "b"},
+		"b": {Inherits: "a"},
+	}
+	if _, err := resolveProfile(profiles, "a"); err == nil {
+		t.Fatal("expected an error for circular profile inheritance")
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectDefaults is the `defaults:` block of a project's
+// ".autoprat.yaml", letting a repo ship its own default repos,
+// default filters, and default search query alongside its filter and
+// action definitions.
+type ProjectDefaults struct {
+	Repositories []string `yaml:"repositories,omitempty"`
+	Filters      []string `yaml:"filters,omitempty"`
+	SearchQuery  string   `yaml:"search_query,omitempty"`
+}
+
+// loadProjectDefaults reads the `defaults:` block from the project's
+// ".autoprat.yaml", discovered by walking up from $PWD. Returns a zero
+// ProjectDefaults and ok=false if no project config file is found.
+func loadProjectDefaults() (ProjectDefaults, bool, error) {
+	projectDir, ok := findProjectDir()
+	if !ok {
+		return ProjectDefaults{}, false, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(projectDir, ".autoprat.yaml"))
+	if err != nil {
+		// Project config may be a bare ".autoprat/" directory with no
+		// ".autoprat.yaml" file, which has no defaults: block.
+		return ProjectDefaults{}, false, nil
+	}
+
+	var doc struct {
+		Defaults ProjectDefaults `yaml:"defaults"`
+	}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return ProjectDefaults{}, false, fmt.Errorf("failed to parse %s: %w", filepath.Join(projectDir, ".autoprat.yaml"), err)
+	}
+
+	return doc.Defaults, true, nil
+}
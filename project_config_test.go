@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadProjectDefaults(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte(`
+defaults:
+  repositories:
+    - owner/a
+    - owner/b
+  filters:
+    - no-hold
+  search_query: "is:open"
+`)
+	if err := os.WriteFile(filepath.Join(dir, ".autoprat.yaml"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(orig)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	defaults, ok, err := loadProjectDefaults()
+	if err != nil {
+		t.Fatalf("loadProjectDefaults failed: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a project config file to be found")
+	}
+	if len(defaults.Repositories) != 2 || defaults.Repositories[0] != "owner/a" {
+		t.Errorf("Repositories = %v, want [owner/a owner/b]", defaults.Repositories)
+	}
+	if len(defaults.Filters) != 1 || defaults.Filters[0] != "no-hold" {
+		t.Errorf("Filters = %v, want [no-hold]", defaults.Filters)
+	}
+	if defaults.SearchQuery != "is:open" {
+		t.Errorf("SearchQuery = %q, want %q", defaults.SearchQuery, "is:open")
+	}
+}
+
+func TestLoadProjectDefaults_NoProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(orig)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok, err := loadProjectDefaults()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected no project config to be found in an empty temp dir")
+	}
+}
@@ -96,16 +96,283 @@ func (qb *QueryBuilder) Build() string {
 	return strings.Join(qb.terms, " ")
 }
 
-// ParseQuery parses a query string and creates a QueryBuilder.
-// This handles both simple queries and complex expressions.
+// ParseQuery parses query using CompileQuery and returns a
+// QueryBuilder seeded with the resulting GitHub search syntax. query's
+// top-level OR (if any) expands into multiple alternative queries
+// (see ParsedQuery.GitHubQueries); since QueryBuilder can only hold
+// one query string, ParseQuery keeps just the first alternative. Use
+// CompileQuery directly to get the full OR-fanout. If query doesn't
+// parse under CompileQuery's small AND/OR/NOT grammar (e.g. it's
+// already raw GitHub search syntax outside that grammar), it's added
+// verbatim instead.
 func ParseQuery(query string) *QueryBuilder {
 	qb := NewQueryBuilder()
+	if query == "" {
+		return qb
+	}
+
+	parsed, err := CompileQuery(query)
+	if err != nil {
+		qb.AddTerm(query)
+		return qb
+	}
 
-	// For now, just add the raw query
-	// TODO: Implement proper parsing for complex expressions
-	if query != "" {
+	queries, err := parsed.GitHubQueries()
+	if err != nil || len(queries) == 0 {
 		qb.AddTerm(query)
+		return qb
 	}
 
+	qb.AddTerm(queries[0])
 	return qb
 }
+
+// ParsedQuery is a compiled query expression over GitHub search terms,
+// supporting AND, OR, NOT, parentheses, and quoted phrases, e.g.:
+//
+//	label:bug AND NOT label:hold
+//	(author:alice OR author:bob) AND label:needs-review
+//
+// Compile with CompileQuery; translate to GitHub search syntax with
+// GitHubQueries.
+type ParsedQuery struct {
+	root queryNode
+}
+
+// CompileQuery parses src's AND/OR/NOT/parenthesised query expression
+// into a ParsedQuery. Two terms written next to each other with no
+// operator between them are implicitly ANDed, matching plain
+// space-separated GitHub search syntax.
+func CompileQuery(src string) (*ParsedQuery, error) {
+	p := &queryParser{tokens: tokeniseQuery(src), src: src}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in query %q", p.tokens[p.pos], src)
+	}
+	return &ParsedQuery{root: node}, nil
+}
+
+// GitHubQueries translates the parsed expression into one or more
+// GitHub search query strings. GitHub search has no OR or parens of
+// its own, so a top-level OR fans out into one query string per
+// alternative (a disjunctive-normal-form expansion): callers are
+// expected to issue each query separately and union the results,
+// deduplicating by repository+PR number. Returns an error if the
+// expression negates an AND/OR group (e.g. `NOT (a OR b)`), which has
+// no single GitHub search equivalent.
+func (q *ParsedQuery) GitHubQueries() ([]string, error) {
+	alts, err := q.root.expand()
+	if err != nil {
+		return nil, err
+	}
+	queries := make([]string, 0, len(alts))
+	for _, terms := range alts {
+		queries = append(queries, strings.Join(terms, " "))
+	}
+	return queries, nil
+}
+
+// queryNode is one node of a compiled ParsedQuery.
+type queryNode interface {
+	// expand returns this node's disjunctive-normal-form alternatives:
+	// each inner slice is one AND-conjunction of GitHub search terms.
+	// OR contributes additional alternatives; AND (explicit or
+	// implicit via juxtaposition) combines every pair of alternatives
+	// from its two sides.
+	expand() ([][]string, error)
+}
+
+type queryTerm struct{ value string }
+
+func (n queryTerm) expand() ([][]string, error) {
+	return [][]string{{n.value}}, nil
+}
+
+// queryNot implements NOT, only supported on a single term (pushed
+// down into a GitHub search exclusion, e.g. `NOT label:x` ->
+// `-label:x`); negating an AND/OR group has no single-query GitHub
+// equivalent and is rejected.
+type queryNot struct{ operand queryNode }
+
+func (n queryNot) expand() ([][]string, error) {
+	term, ok := n.operand.(queryTerm)
+	if !ok {
+		return nil, fmt.Errorf("NOT is only supported on a single term, not a parenthesised AND/OR group")
+	}
+	return [][]string{{negateTerm(term.value)}}, nil
+}
+
+func negateTerm(term string) string {
+	if strings.HasPrefix(term, "-") {
+		return strings.TrimPrefix(term, "-")
+	}
+	return "-" + term
+}
+
+type queryAnd struct{ left, right queryNode }
+
+func (n queryAnd) expand() ([][]string, error) {
+	leftAlts, err := n.left.expand()
+	if err != nil {
+		return nil, err
+	}
+	rightAlts, err := n.right.expand()
+	if err != nil {
+		return nil, err
+	}
+
+	var combined [][]string
+	for _, l := range leftAlts {
+		for _, r := range rightAlts {
+			terms := make([]string, 0, len(l)+len(r))
+			terms = append(terms, l...)
+			terms = append(terms, r...)
+			combined = append(combined, terms)
+		}
+	}
+	return combined, nil
+}
+
+type queryOr struct{ left, right queryNode }
+
+func (n queryOr) expand() ([][]string, error) {
+	leftAlts, err := n.left.expand()
+	if err != nil {
+		return nil, err
+	}
+	rightAlts, err := n.right.expand()
+	if err != nil {
+		return nil, err
+	}
+	return append(leftAlts, rightAlts...), nil
+}
+
+type queryParser struct {
+	tokens []string
+	pos    int
+	src    string
+}
+
+func (p *queryParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+// parseOr has the lowest precedence: a OR b OR c.
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "OR" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = queryOr{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd binds tighter than OR: a AND b, or the implicit "a b".
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek() {
+		case "", "OR", ")":
+			return left, nil
+		case "AND":
+			p.next()
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = queryAnd{left, right}
+	}
+}
+
+// parseUnary binds tighter than AND: NOT a.
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.peek() == "NOT" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	tok := p.next()
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("unexpected end of query %q", p.src)
+	case "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing closing paren in query %q", p.src)
+		}
+		return expr, nil
+	case "AND", "OR", "NOT", ")":
+		return nil, fmt.Errorf("unexpected token %q in query %q", tok, p.src)
+	default:
+		return queryTerm{value: tok}, nil
+	}
+}
+
+// tokeniseQuery splits a query expression into tokens: bare/quoted
+// search terms and the AND, OR, NOT, (, ) operators.
+func tokeniseQuery(src string) []string {
+	var tokens []string
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t':
+			continue
+		case r == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j+1]))
+			i = j
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+		default:
+			j := i
+			for j < len(runes) && runes[j] != ' ' && runes[j] != '\t' && runes[j] != '(' && runes[j] != ')' && runes[j] != '"' {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j - 1
+		}
+	}
+
+	return tokens
+}
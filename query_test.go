@@ -244,6 +244,150 @@ func TestQueryBuilderChaining(t *testing.T) {
 	}
 }
 
+func TestCompileQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "implicit AND",
+			query: `label:bug label:priority`,
+			want:  []string{"label:bug label:priority"},
+		},
+		{
+			name:  "explicit AND",
+			query: `label:bug AND label:priority`,
+			want:  []string{"label:bug label:priority"},
+		},
+		{
+			name:  "negation pushdown",
+			query: `NOT label:hold`,
+			want:  []string{"-label:hold"},
+		},
+		{
+			name:  "double negation cancels",
+			query: `NOT -label:hold`,
+			want:  []string{"label:hold"},
+		},
+		{
+			name:  "negation pushdown on parenthesised term",
+			query: `label:bug AND NOT (label:hold)`,
+			want:  []string{"label:bug -label:hold"},
+		},
+		{
+			name:  "OR fanout",
+			query: `author:alice OR author:bob`,
+			want:  []string{"author:alice", "author:bob"},
+		},
+		{
+			name:  "AND binds tighter than OR",
+			query: `author:alice AND label:bug OR author:bob`,
+			want:  []string{"author:alice label:bug", "author:bob"},
+		},
+		{
+			name:  "parens override precedence",
+			query: `author:alice AND (label:bug OR label:feature)`,
+			want:  []string{"author:alice label:bug", "author:alice label:feature"},
+		},
+		{
+			name:  "quoted phrase",
+			query: `"needs review"`,
+			want:  []string{`"needs review"`},
+		},
+		{
+			name:    "NOT over an OR group is rejected",
+			query:   `NOT (author:alice OR author:bob)`,
+			wantErr: true,
+		},
+		{
+			name:    "missing closing paren",
+			query:   `(label:bug`,
+			wantErr: true,
+		},
+		{
+			name:    "dangling operator",
+			query:   `label:bug AND`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := CompileQuery(tt.query)
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatalf("CompileQuery(%q) failed: %v", tt.query, err)
+				}
+				return
+			}
+
+			got, err := parsed.GitHubQueries()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GitHubQueries() for %q expected an error, got none", tt.query)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GitHubQueries() for %q failed: %v", tt.query, err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("GitHubQueries() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("GitHubQueries()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseQuery_ORFanoutUsesFirstAlternative(t *testing.T) {
+	qb := ParseQuery(`author:alice OR author:bob`)
+	if got, want := qb.Build(), "author:alice"; got != want {
+		t.Errorf("ParseQuery(...).Build() = %q, want %q", got, want)
+	}
+}
+
+func TestDedupePRsByNumber(t *testing.T) {
+	prs := []PullRequest{
+		{Number: 1},
+		{Number: 2},
+		{Number: 1},
+	}
+	deduped := dedupePRsByNumber(prs)
+	if len(deduped) != 2 {
+		t.Fatalf("dedupePRsByNumber() = %v, want 2 entries", deduped)
+	}
+	if deduped[0].Number != 1 || deduped[1].Number != 2 {
+		t.Errorf("dedupePRsByNumber() = %v, want [{1} {2}]", deduped)
+	}
+}
+
+func TestExpandSearchQuery(t *testing.T) {
+	queries, err := expandSearchQuery(`author:alice OR author:bob`)
+	if err != nil {
+		t.Fatalf("expandSearchQuery failed: %v", err)
+	}
+	if len(queries) != 2 || queries[0] != "author:alice" || queries[1] != "author:bob" {
+		t.Errorf("expandSearchQuery = %v, want [author:alice author:bob]", queries)
+	}
+}
+
+func TestExpandSearchQuery_Empty(t *testing.T) {
+	queries, err := expandSearchQuery("")
+	if err != nil {
+		t.Fatalf("expandSearchQuery failed: %v", err)
+	}
+	if len(queries) != 1 || queries[0] != "" {
+		t.Errorf("expandSearchQuery(\"\") = %v, want [\"\"]", queries)
+	}
+}
+
 func TestQueryBuilderEdgeCases(t *testing.T) {
 	tests := []struct {
 		name     string
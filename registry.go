@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"text/template"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -22,7 +24,34 @@ type ActionDefinition struct {
 	Comment     string `yaml:"comment"`
 	Label       string `yaml:"label"`
 	Predicate   string `yaml:"predicate"`
-	Source      string `yaml:"-"` // "embedded" or "user", not serialized.
+	When        string `yaml:"when,omitempty"`
+	SkipWhen    string `yaml:"skip_when,omitempty"`
+
+	// Cooldown overrides Config.Throttle for this action in watch
+	// mode, e.g. "2h". Empty means "use Config.Throttle".
+	Cooldown string `yaml:"cooldown,omitempty"`
+
+	// Steps and DependsOn describe a multi-step workflow (e.g. label,
+	// then approve, then lgtm) in place of a single Comment. When Steps
+	// is non-empty, Comment/Label/Predicate/When/SkipWhen are ignored.
+	Steps     []StepDefinition `yaml:"steps,omitempty"`
+	DependsOn []string         `yaml:"depends_on,omitempty"`
+
+	Source string `yaml:"-"` // "embedded", "user", or "project", not serialized.
+
+	// whenExpr and skipWhenExpr are the compiled forms of When and
+	// SkipWhen, populated by validateAction at load time.
+	whenExpr     PredicateExpr
+	skipWhenExpr PredicateExpr
+
+	// commentTmpl is the compiled form of Comment, populated by
+	// validateAction at load time. Nil for static (non-templated)
+	// comments.
+	commentTmpl *template.Template
+
+	// cooldownDur is the parsed form of Cooldown, populated by
+	// validateAction at load time.
+	cooldownDur time.Duration
 }
 
 // ToAction converts an ActionDefinition to the runtime Action type.
@@ -38,9 +67,13 @@ func (ad ActionDefinition) ToAction() Action {
 	}
 
 	return Action{
-		Comment:   ad.Comment,
-		Label:     ad.Label,
-		Predicate: predicate,
+		Comment:         ad.Comment,
+		Label:           ad.Label,
+		Predicate:       predicate,
+		When:            ad.whenExpr,
+		SkipWhen:        ad.skipWhenExpr,
+		CommentTemplate: ad.commentTmpl,
+		Cooldown:        ad.cooldownDur,
 	}
 }
 
@@ -51,7 +84,8 @@ const (
 	ActionLoadNothing  ActionLoadMode = 0 // Load nothing
 	ActionLoadEmbedded ActionLoadMode = 1 // Load embedded actions only
 	ActionLoadUser     ActionLoadMode = 2 // Load user actions only
-	ActionLoadAll      ActionLoadMode = 3 // Load embedded + user actions
+	ActionLoadProject  ActionLoadMode = 4 // Load project-local actions only
+	ActionLoadAll      ActionLoadMode = ActionLoadEmbedded | ActionLoadUser | ActionLoadProject
 )
 
 // Registry holds all available actions loaded from embedded and user
@@ -87,6 +121,13 @@ func NewRegistryWithMode(mode ActionLoadMode) (*Registry, error) {
 		}
 	}
 
+	// Load project-local actions if requested.
+	if mode&ActionLoadProject != 0 {
+		if err := r.loadProjectActions(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load project actions: %v\n", err)
+		}
+	}
+
 	return r, nil
 }
 
@@ -112,7 +153,7 @@ func (r *Registry) loadEmbeddedActions() error {
 			return fmt.Errorf("failed to parse embedded action file %s: %w", entry.Name(), err)
 		}
 
-		if err := r.validateAction(action); err != nil {
+		if err := r.validateAction(&action); err != nil {
 			return fmt.Errorf("invalid embedded action %s: %w", entry.Name(), err)
 		}
 
@@ -158,7 +199,7 @@ func (r *Registry) loadUserActions() error {
 			return fmt.Errorf("failed to parse user action file %s: %w", entry.Name(), err)
 		}
 
-		if err := r.validateAction(action); err != nil {
+		if err := r.validateAction(&action); err != nil {
 			return fmt.Errorf("invalid user action %s: %w", entry.Name(), err)
 		}
 
@@ -170,8 +211,69 @@ func (r *Registry) loadUserActions() error {
 	return nil
 }
 
+// loadProjectActions loads actions that a repo ships for itself,
+// discovered by walking up from $PWD for a ".autoprat/actions/*.yaml"
+// directory and/or a ".autoprat.yaml" file with a top-level
+// "actions:" list. Project actions override user and embedded ones of
+// the same flag, and are tagged Source "project".
+func (r *Registry) loadProjectActions() error {
+	projectDir, ok := findProjectDir()
+	if !ok {
+		return nil
+	}
+
+	actionsDir := filepath.Join(projectDir, ".autoprat", "actions")
+	if entries, err := os.ReadDir(actionsDir); err == nil {
+		for _, entry := range entries {
+			if !strings.HasSuffix(entry.Name(), ".yaml") {
+				continue
+			}
+
+			content, err := os.ReadFile(filepath.Join(actionsDir, entry.Name()))
+			if err != nil {
+				return fmt.Errorf("failed to read project action file %s: %w", entry.Name(), err)
+			}
+
+			var action ActionDefinition
+			if err := yaml.Unmarshal(content, &action); err != nil {
+				return fmt.Errorf("failed to parse project action file %s: %w", entry.Name(), err)
+			}
+
+			if err := r.validateAction(&action); err != nil {
+				return fmt.Errorf("invalid project action %s: %w", entry.Name(), err)
+			}
+
+			action.Source = "project"
+			r.actions[action.Flag] = action
+		}
+	}
+
+	configFile := filepath.Join(projectDir, ".autoprat.yaml")
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil
+	}
+
+	var doc struct {
+		Actions []ActionDefinition `yaml:"actions"`
+	}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", configFile, err)
+	}
+
+	for _, action := range doc.Actions {
+		if err := r.validateAction(&action); err != nil {
+			return fmt.Errorf("invalid action %q in %s: %w", action.Name, configFile, err)
+		}
+		action.Source = "project"
+		r.actions[action.Flag] = action
+	}
+
+	return nil
+}
+
 // validateAction ensures an action definition is valid.
-func (r *Registry) validateAction(action ActionDefinition) error {
+func (r *Registry) validateAction(action *ActionDefinition) error {
 	if action.Name == "" {
 		return fmt.Errorf("action name is required")
 	}
@@ -184,8 +286,14 @@ func (r *Registry) validateAction(action ActionDefinition) error {
 		return fmt.Errorf("action description is required")
 	}
 
-	if action.Comment == "" {
-		return fmt.Errorf("action comment is required")
+	if action.Comment == "" && len(action.Steps) == 0 {
+		return fmt.Errorf("action comment or steps is required")
+	}
+
+	for i, step := range action.Steps {
+		if step.Comment == "" {
+			return fmt.Errorf("step %d is missing a comment", i)
+		}
 	}
 
 	// Validate predicate if specified.
@@ -202,6 +310,42 @@ func (r *Registry) validateAction(action ActionDefinition) error {
 		}
 	}
 
+	// Parse the when:/skip_when: DSL once at load time and cache the
+	// compiled AST on the definition.
+	if action.When != "" {
+		expr, err := CompilePredicate(action.When)
+		if err != nil {
+			return fmt.Errorf("invalid when expression: %w", err)
+		}
+		action.whenExpr = expr
+	}
+	if action.SkipWhen != "" {
+		expr, err := CompilePredicate(action.SkipWhen)
+		if err != nil {
+			return fmt.Errorf("invalid skip_when expression: %w", err)
+		}
+		action.skipWhenExpr = expr
+	}
+
+	// Parse Comment as a text/template once at load time so rendering
+	// at post-time is just tmpl.Execute. Static comments (no "{{")
+	// are left uncompiled.
+	if action.Comment != "" {
+		tmpl, err := CompileCommentTemplate(action.Flag, action.Comment)
+		if err != nil {
+			return fmt.Errorf("invalid comment template: %w", err)
+		}
+		action.commentTmpl = tmpl
+	}
+
+	if action.Cooldown != "" {
+		dur, err := time.ParseDuration(action.Cooldown)
+		if err != nil {
+			return fmt.Errorf("invalid cooldown %q: %w", action.Cooldown, err)
+		}
+		action.cooldownDur = dur
+	}
+
 	return nil
 }
 
@@ -234,6 +378,33 @@ func (r *Registry) GetFlags() []string {
 	return flags
 }
 
+// findProjectDir walks upward from $PWD looking for a ".autoprat.yaml"
+// file or ".autoprat" directory, returning the first directory that
+// has either. This lets a repo ship its own actions (and, via the
+// filters and search packages, its own filters/templates) from any
+// subdirectory of the checkout, the way .git is discovered.
+func findProjectDir() (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".autoprat.yaml")); err == nil {
+			return dir, true
+		}
+		if info, err := os.Stat(filepath.Join(dir, ".autoprat")); err == nil && info.IsDir() {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
 // GetFlagsBySource returns flag names for actions from a specific
 // source, sorted.
 func (r *Registry) GetFlagsBySource(source string) []string {
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -62,3 +64,42 @@ func TestNewRegistryWithMode(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadProjectActions_AutopratYAML(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte(`
+actions:
+  - name: project-approve
+    flag: project-approve
+    description: Approve per the project's own policy
+    comment: /approve
+`)
+	if err := os.WriteFile(filepath.Join(dir, ".autoprat.yaml"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(orig)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Registry{actions: make(map[string]ActionDefinition)}
+	if err := r.loadProjectActions(); err != nil {
+		t.Fatalf("loadProjectActions failed: %v", err)
+	}
+
+	action, ok := r.GetAction("project-approve")
+	if !ok {
+		t.Fatal("expected project-approve action to be loaded")
+	}
+	if action.Source != "project" {
+		t.Errorf("Source = %q, want %q", action.Source, "project")
+	}
+	if got := r.GetFlagsBySource("project"); len(got) != 1 || got[0] != "project-approve" {
+		t.Errorf("GetFlagsBySource(\"project\") = %v, want [project-approve]", got)
+	}
+}
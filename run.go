@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sync"
+	"os"
+	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // RepositoryPRs holds PRs from a specific repository.
@@ -22,33 +26,106 @@ type PRResult struct {
 
 // CommandResult contains commands to be executed.
 type CommandResult struct {
-	Commands []string
+	Commands []CommandEntry
+}
+
+// CommandEntry pairs one generated action command with the PR it
+// targets, so --output=json/ndjson can report "pr" alongside "command"
+// for orchestrators that want to execute and retry each command under
+// their own policy instead of shelling out to bash.
+type CommandEntry struct {
+	PR      int    `json:"pr"`
+	Command string `json:"command"`
+}
+
+// ContextResult contains the rendered comment-template context for each
+// matched PR, produced when config.PrintContext is set so users can
+// debug their `comment:` YAML templates without posting anything.
+type ContextResult struct {
+	Contexts []PRContext
+}
+
+// PRContext pairs a PR with the CommentContext it would render against.
+type PRContext struct {
+	Repository string
+	PRNumber   int
+	Context    CommentContext
 }
 
 // Run executes the main application logic and returns structured data.
 func Run(ctx context.Context, config *Config, clientFactory func(repo string) (GitHubClient, error)) (Result, error) {
 	// Fetch PRs from all repositories
-	allRepositoryPRs, err := fetchAllRepositoryPRsWithSearch(ctx, config.Repositories, config.SearchQuery, clientFactory)
+	allRepositoryPRs, err := fetchAllRepositoryPRsWithSearch(ctx, config.Repositories, config.SearchQuery, clientFactory, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch PRs: %w", err)
+		if config.PartialResults && allRepositoryPRs != nil {
+			fmt.Fprintf(os.Stderr, "Warning: some repositories failed to fetch, continuing with partial results: %v\n", err)
+		} else {
+			return nil, fmt.Errorf("failed to fetch PRs: %w", err)
+		}
 	}
 
 	// Apply PR-specific filtering
 	filteredPRs := applyPRFiltering(allRepositoryPRs, config)
 
-	// Determine result type based on config
+	if config.PrintContext {
+		var contexts []PRContext
+		for _, repoPRs := range filteredPRs {
+			for _, prItem := range repoPRs.PRs {
+				contexts = append(contexts, PRContext{
+					Repository: repoPRs.Repository,
+					PRNumber:   prItem.Number,
+					Context:    NewCommentContext(prItem, repoPRs.Repository),
+				})
+			}
+		}
+		return ContextResult{Contexts: contexts}, nil
+	}
+
+	if len(config.WorkflowActions) > 0 {
+		steps, err := OrderWorkflowSteps(config.WorkflowActions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to order workflow steps: %w", err)
+		}
+
+		var results []WorkflowResult
+		for _, repoPRs := range filteredPRs {
+			for _, prItem := range repoPRs.PRs {
+				results = append(results, WorkflowResult{
+					Repository: repoPRs.Repository,
+					PRNumber:   prItem.Number,
+					Steps:      PlanWorkflow(steps, prItem, config.Throttle),
+				})
+			}
+		}
+		return results, nil
+	}
+
+	// --output=json/ndjson wants the full JSONPR shape (commands plus
+	// a machine-readable reason for every skipped action), so it must
+	// be checked before the bare CommandResult actions branch below.
+	if config.Output == "json" || config.Output == "ndjson" {
+		return BuildJSONResult(filteredPRs, config)
+	}
+
 	if len(config.Actions) > 0 {
 		// Generate commands
-		var commands []string
+		var commands []CommandEntry
 		for _, repoPRs := range filteredPRs {
 			for _, prItem := range repoPRs.PRs {
-				toPost := FilterActions(config.Actions, prItem.Labels)
+				toPost := FilterActions(config.Actions, prItem)
 				for _, a := range toPost {
-					if config.Throttle > 0 && HasRecentComment(prItem, a.Comment, config.Throttle) {
+					comment, err := a.Render(prItem, repoPRs.Repository)
+					if err != nil {
+						return nil, fmt.Errorf("failed to render comment for %s#%d: %w", repoPRs.Repository, prItem.Number, err)
+					}
+					if config.Throttle > 0 && HasRecentComment(prItem, comment, config.Throttle) {
 						// Skip throttled comments - could add debug info to result
 						continue
 					}
-					commands = append(commands, a.Command(repoPRs.Repository, prItem.Number))
+					commands = append(commands, CommandEntry{
+						PR:      prItem.Number,
+						Command: a.CommandWithComment(repoPRs.Repository, prItem.Number, comment),
+					})
 				}
 			}
 		}
@@ -59,58 +136,180 @@ func Run(ctx context.Context, config *Config, clientFactory func(repo string) (G
 	return PRResult{RepositoryPRs: filteredPRs}, nil
 }
 
-// fetchAllRepositoryPRsWithSearch fetches PRs from all repositories using the search API.
-func fetchAllRepositoryPRsWithSearch(ctx context.Context, repositories []string, searchQuery string, clientFactory func(repo string) (GitHubClient, error)) ([]RepositoryPRs, error) {
-	var allRepositoryPRs []RepositoryPRs
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	errChan := make(chan error, len(repositories))
+// defaultFetchRetries is how many attempts fetchRepositoryPRsWithRetry
+// makes before giving up on a single repository.
+const defaultFetchRetries = 4
 
-	for _, repository := range repositories {
-		wg.Add(1)
-		go func(repo string) {
-			defer wg.Done()
+// fetchAllRepositoryPRsWithSearch fetches PRs from all repositories
+// using the search API, bounded by config.MaxConcurrency concurrent
+// fetches via an errgroup semaphore. Results are collected into a
+// slice indexed by each repository's position in `repositories`, so
+// ordering is deterministic regardless of completion order. All
+// per-repository errors are collected via errors.Join rather than
+// returning only the first one seen; when config.PartialResults is
+// set, the successfully-fetched repositories are still returned
+// alongside the joined error.
+func fetchAllRepositoryPRsWithSearch(ctx context.Context, repositories []string, searchQuery string, clientFactory func(repo string) (GitHubClient, error), config *Config) ([]RepositoryPRs, error) {
+	queries, err := expandSearchQuery(searchQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse search query %q: %w", searchQuery, err)
+	}
 
+	maxConcurrency := config.MaxConcurrency
+	if maxConcurrency <= 0 || maxConcurrency > len(repositories) {
+		maxConcurrency = len(repositories)
+	}
+	if maxConcurrency == 0 {
+		maxConcurrency = 1
+	}
+
+	results := make([]*RepositoryPRs, len(repositories))
+	errs := make([]error, len(repositories))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrency)
+
+	for i, repository := range repositories {
+		i, repo := i, repository
+		g.Go(func() error {
 			client, err := clientFactory(repo)
 			if err != nil {
-				errChan <- fmt.Errorf("failed to create client for %s: %v", repo, err)
-				return
+				errs[i] = fmt.Errorf("failed to create client for %s: %w", repo, err)
+				return nil
 			}
 
-			prs, err := client.Search(ctx, searchQuery)
-			if err != nil {
-				errChan <- fmt.Errorf("failed to search PRs for %s: %v", repo, err)
-				return
+			var prs []PullRequest
+			for _, q := range queries {
+				batch, err := fetchRepositoryPRsWithRetry(gctx, client, q)
+				if err != nil {
+					errs[i] = fmt.Errorf("failed to search PRs for %s: %w", repo, err)
+					return nil
+				}
+				prs = append(prs, batch...)
 			}
 
-			mu.Lock()
-			allRepositoryPRs = append(allRepositoryPRs, RepositoryPRs{
-				Repository: repo,
-				PRs:        prs,
-			})
-			mu.Unlock()
-		}(repository)
+			results[i] = &RepositoryPRs{Repository: repo, PRs: dedupePRsByNumber(prs)}
+			return nil
+		})
 	}
 
-	wg.Wait()
-	close(errChan)
+	// g.Wait() only ever returns an error from a g.Go func that
+	// itself returns non-nil, which none of ours do (errors are
+	// recorded per-index above instead), so it's always nil here.
+	_ = g.Wait()
 
-	for err := range errChan {
-		return nil, err
+	var allRepositoryPRs []RepositoryPRs
+	var joinedErrs []error
+	for i, result := range results {
+		if result != nil {
+			allRepositoryPRs = append(allRepositoryPRs, *result)
+		}
+		if errs[i] != nil {
+			joinedErrs = append(joinedErrs, errs[i])
+		}
 	}
 
-	// Sort results for consistent ordering
-	for i := 0; i < len(allRepositoryPRs); i++ {
-		for j := i + 1; j < len(allRepositoryPRs); j++ {
-			if allRepositoryPRs[i].Repository > allRepositoryPRs[j].Repository {
-				allRepositoryPRs[i], allRepositoryPRs[j] = allRepositoryPRs[j], allRepositoryPRs[i]
-			}
+	if len(joinedErrs) > 0 {
+		joined := errors.Join(joinedErrs...)
+		if config.PartialResults {
+			return allRepositoryPRs, joined
 		}
+		return nil, joined
 	}
 
 	return allRepositoryPRs, nil
 }
 
+// expandSearchQuery compiles searchQuery's AND/OR/NOT query grammar
+// via CompileQuery and fans it out into one or more GitHub search
+// strings (see ParsedQuery.GitHubQueries). An empty searchQuery passes
+// through as a single empty query; a searchQuery CompileQuery can't
+// parse (e.g. it's raw GitHub search syntax outside that small
+// grammar) passes through unchanged as a single query, so the common
+// case of a plain space-separated query is unaffected.
+func expandSearchQuery(searchQuery string) ([]string, error) {
+	if searchQuery == "" {
+		return []string{""}, nil
+	}
+
+	parsed, err := CompileQuery(searchQuery)
+	if err != nil {
+		return []string{searchQuery}, nil
+	}
+
+	return parsed.GitHubQueries()
+}
+
+// dedupePRsByNumber removes duplicate PRs by Number, keeping the first
+// occurrence. Needed once a search query's OR expands into multiple
+// GitHub queries against the same repository, since the same PR can
+// legitimately satisfy more than one alternative.
+func dedupePRsByNumber(prs []PullRequest) []PullRequest {
+	seen := make(map[int]bool, len(prs))
+	deduped := make([]PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if seen[pr.Number] {
+			continue
+		}
+		seen[pr.Number] = true
+		deduped = append(deduped, pr)
+	}
+	return deduped
+}
+
+// fetchRepositoryPRsWithRetry retries client.Search with exponential
+// backoff, waiting for the RetryAfter cool-down instead when GitHub's
+// secondary rate limit asks for one, and giving up early if ctx is
+// cancelled.
+func fetchRepositoryPRsWithRetry(ctx context.Context, client GitHubClient, searchQuery string) ([]PullRequest, error) {
+	backoff := 500 * time.Millisecond
+
+	var lastErr error
+	for attempt := 0; attempt < defaultFetchRetries; attempt++ {
+		if attempt > 0 {
+			wait := backoff
+			var rateLimitErr *SecondaryRateLimitError
+			if errors.As(lastErr, &rateLimitErr) && rateLimitErr.RetryAfter > 0 {
+				wait = rateLimitErr.RetryAfter
+			}
+
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			backoff *= 2
+		}
+
+		prs, err := client.Search(ctx, searchQuery)
+		if err == nil {
+			return prs, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	return nil, lastErr
+}
+
+// SecondaryRateLimitError indicates GitHub asked the caller to back
+// off for a specific duration, per its secondary rate limit headers.
+type SecondaryRateLimitError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *SecondaryRateLimitError) Error() string {
+	return fmt.Sprintf("secondary rate limit, retry after %s: %v", e.RetryAfter, e.Err)
+}
+
+func (e *SecondaryRateLimitError) Unwrap() error {
+	return e.Err
+}
+
 // applyPRFiltering applies PR-specific filtering when specific PRs are requested.
 func applyPRFiltering(allRepositoryPRs []RepositoryPRs, config *Config) []RepositoryPRs {
 	for i := range allRepositoryPRs {
@@ -135,6 +334,21 @@ func applyPRFiltering(allRepositoryPRs []RepositoryPRs, config *Config) []Reposi
 			}
 		}
 
+		if config.Filter != nil {
+			var filtered []PullRequest
+			for _, pr := range prs {
+				matched, err := config.Filter.Evaluate(pr)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: --filter error on %s#%d: %v\n", allRepositoryPRs[i].Repository, pr.Number, err)
+					continue
+				}
+				if matched {
+					filtered = append(filtered, pr)
+				}
+			}
+			prs = filtered
+		}
+
 		allRepositoryPRs[i].PRs = prs
 	}
 
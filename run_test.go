@@ -122,14 +122,65 @@ func TestRun_WithActions(t *testing.T) {
 	// Commands should contain the PR URL and action
 	expected := `gh pr comment --repo owner/repo 456 --body "/approve"`
 	found := false
-	for _, cmd := range cmdResult.Commands {
-		if cmd == expected {
+	for _, entry := range cmdResult.Commands {
+		if entry.Command == expected && entry.PR == 456 {
 			found = true
 			break
 		}
 	}
 	if !found {
-		t.Errorf("Expected command %q not found in: %v", expected, cmdResult.Commands)
+		t.Errorf("Expected command %q for PR 456 not found in: %v", expected, cmdResult.Commands)
+	}
+}
+
+func TestRun_WithActionsAndJSONOutput(t *testing.T) {
+	ctx := context.Background()
+
+	mockPR := PullRequest{
+		Number:      789,
+		Title:       "Test PR with Actions and JSON output",
+		URL:         "https://github.com/owner/repo/pull/789",
+		AuthorLogin: "testuser",
+	}
+
+	mockClient := &mockGitHubClient{
+		searchFunc: func(ctx context.Context, query string) ([]PullRequest, error) {
+			return []PullRequest{mockPR}, nil
+		},
+	}
+
+	config := &Config{
+		Repositories: []string{"owner/repo"},
+		SearchQuery:  "is:pr is:open",
+		Output:       "json",
+		Actions: []Action{
+			{Comment: "/approve", Predicate: PredicateNone},
+		},
+	}
+
+	result, err := Run(ctx, config, mockClientFactory(mockClient))
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	// --output=json must win over the bare CommandResult shape even
+	// when actions are configured, so the skipped-action reasons in
+	// JSONPR.SkippedActions stay reachable.
+	jsonResult, ok := result.(JSONResult)
+	if !ok {
+		t.Fatalf("Expected JSONResult, got %T", result)
+	}
+
+	if len(jsonResult.Repositories) != 1 || len(jsonResult.Repositories[0].PRs) != 1 {
+		t.Fatalf("Expected 1 repository with 1 PR, got %+v", jsonResult)
+	}
+
+	pr := jsonResult.Repositories[0].PRs[0]
+	if pr.Number != 789 {
+		t.Errorf("Expected PR number 789, got %d", pr.Number)
+	}
+	if len(pr.Commands) != 1 || pr.Commands[0] != `gh pr comment --repo owner/repo 789 --body "/approve"` {
+		t.Errorf("Expected a single /approve command, got %v", pr.Commands)
 	}
 }
 
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// defaultTemplatesDir returns where --template looks for user-defined
+// verbose templates: $XDG_CONFIG_HOME/autoprat/templates, or
+// $HOME/.config/autoprat/templates if unset.
+func defaultTemplatesDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "autoprat", "templates")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autoprat", "templates")
+}
+
+// loadTemplateOverrides reads every "*.tmpl" file in
+// defaultTemplatesDir(), keyed by filename without extension, so
+// "review-queue.tmpl" becomes selectable as --template=review-queue.
+// Returns a nil map if the directory doesn't exist.
+func loadTemplateOverrides() (map[string]string, error) {
+	dir := defaultTemplatesDir()
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil
+	}
+
+	overrides := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tmpl") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template override %s: %w", entry.Name(), err)
+		}
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+		overrides[name] = string(content)
+	}
+	return overrides, nil
+}
+
+// resolveVerboseTemplate picks which verbose.tmpl body to render:
+// name selects a user override from defaultTemplatesDir(), and ""
+// means the embedded default.
+func resolveVerboseTemplate(name string) (string, error) {
+	if name == "" {
+		return verboseTemplate, nil
+	}
+
+	overrides, err := loadTemplateOverrides()
+	if err != nil {
+		return "", err
+	}
+	tmpl, ok := overrides[name]
+	if !ok {
+		return "", fmt.Errorf("unknown --template %q; no %s.tmpl found in %s", name, name, defaultTemplatesDir())
+	}
+	return tmpl, nil
+}
+
+// ListTemplateOverrideNames returns override template names in sorted
+// order.
+func ListTemplateOverrideNames(overrides map[string]string) []string {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
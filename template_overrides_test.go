@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveVerboseTemplate_Default(t *testing.T) {
+	tmpl, err := resolveVerboseTemplate("")
+	if err != nil {
+		t.Fatalf("resolveVerboseTemplate(\"\") failed: %v", err)
+	}
+	if tmpl != verboseTemplate {
+		t.Error("expected the embedded default template when no name is given")
+	}
+}
+
+func TestResolveVerboseTemplate_Override(t *testing.T) {
+	dir := t.TempDir()
+	templatesDir := filepath.Join(dir, "autoprat", "templates")
+	if err := os.MkdirAll(templatesDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(templatesDir, "review-queue.tmpl"), []byte("PR #{{ .Number }}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	tmpl, err := resolveVerboseTemplate("review-queue")
+	if err != nil {
+		t.Fatalf("resolveVerboseTemplate failed: %v", err)
+	}
+	if tmpl != "PR #{{ .Number }}\n" {
+		t.Errorf("tmpl = %q, want the override file's content", tmpl)
+	}
+}
+
+func TestResolveVerboseTemplate_UnknownName(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, err := resolveVerboseTemplate("does-not-exist"); err == nil {
+		t.Fatal("expected an error for an unknown --template name")
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/frobware/autoprat/github/search"
+)
+
+// runTemplatesCommand handles the `autoprat templates ...` subcommand
+// family. args excludes the program name and the leading "templates"
+// argument.
+func runTemplatesCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: autoprat templates <validate|init> ...")
+	}
+
+	switch args[0] {
+	case "validate":
+		return runTemplatesValidate(args[1:])
+	case "init":
+		return runTemplatesInit(args[1:])
+	default:
+		return fmt.Errorf("unknown templates subcommand %q", args[0])
+	}
+}
+
+// runTemplatesValidate implements `autoprat templates validate
+// [files...]`, reporting every ValidationIssue found across the given
+// template files so authors see everything wrong in one pass rather
+// than fixing files one error at a time.
+func runTemplatesValidate(args []string) error {
+	format := "text"
+	var files []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--format" && i+1 < len(args) {
+			format = args[i+1]
+			i++
+			continue
+		}
+		files = append(files, args[i])
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("usage: autoprat templates validate [--format json] [files...]")
+	}
+
+	results := make(map[string][]search.ValidationIssue, len(files))
+	anyIssues := false
+	for _, path := range files {
+		issues := search.ValidateTemplateFile(path)
+		results[path] = issues
+		if len(issues) > 0 {
+			anyIssues = true
+		}
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+	case "text":
+		for _, path := range files {
+			issues := results[path]
+			if len(issues) == 0 {
+				fmt.Printf("%s: ok\n", path)
+				continue
+			}
+			for _, issue := range issues {
+				fmt.Println(issue.String())
+			}
+		}
+	default:
+		return fmt.Errorf("unknown --format %q, expected text or json", format)
+	}
+
+	if anyIssues {
+		os.Exit(1)
+	}
+	return nil
+}
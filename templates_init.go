@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/frobware/autoprat/github/search"
+	"gopkg.in/yaml.v3"
+)
+
+// templateKind names one of the starting points offered by `autoprat
+// templates init`, each pre-filling the query_template skeleton for a
+// common kind of filter.
+type templateKind struct {
+	description   string
+	parameterized bool
+	multiple      bool
+	queryTemplate string
+}
+
+// templateKinds is the menu of starting points `autoprat templates
+// init` offers via --kind, each pre-filled with the query_template
+// skeleton for that kind of filter.
+var templateKinds = map[string]templateKind{
+	"label-filter": {
+		description:   "Filter by a single label",
+		parameterized: true,
+		queryTemplate: "{{label .Value}}",
+	},
+	"author-filter": {
+		description:   "Filter by PR author",
+		parameterized: true,
+		queryTemplate: "{{author .Value}}",
+	},
+	"text-in-title": {
+		description:   "Filter by text appearing in the PR title",
+		parameterized: true,
+		queryTemplate: "{{quote .Value}} in:title",
+	},
+	"parameterized-single-value": {
+		description:   "A single free-form search term",
+		parameterized: true,
+		queryTemplate: "{{.Value}}",
+	},
+	"parameterized-label-list": {
+		description:   "Filter by a list of labels",
+		parameterized: true,
+		multiple:      true,
+		queryTemplate: "{{labels .Values}}",
+	},
+}
+
+// templatesInitUsage documents the starting points accepted by --kind.
+func templatesInitUsage() string {
+	kinds := make([]string, 0, len(templateKinds))
+	for name := range templateKinds {
+		kinds = append(kinds, name)
+	}
+	sort.Strings(kinds)
+	return fmt.Sprintf("usage: autoprat templates init <flag-name> --kind <%s> [--query-template TPL] [--description DESC] [--name NAME] [--flag-short X] [--force]", strings.Join(kinds, "|"))
+}
+
+// userTemplateDir resolves where `autoprat templates init` writes new
+// template files: $XDG_CONFIG_HOME/autoprat/templates, or
+// $HOME/.config/autoprat/templates if unset. This mirrors
+// github/search's own (unexported) userTemplatesDir, duplicated here
+// since package main can't import it.
+func userTemplateDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "autoprat", "templates")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autoprat", "templates")
+}
+
+// runTemplatesInit implements `autoprat templates init <flag-name>`: it
+// writes a new QueryTemplate YAML file, pre-filled from a --kind
+// skeleton and overridden by any flags given, into userTemplateDir(),
+// then round-trips it through search.ValidateTemplateFile so authors
+// get immediate feedback on a bad definition instead of discovering it
+// next time autoprat runs.
+func runTemplatesInit(args []string) error {
+	if len(args) == 0 || len(args[0]) == 0 || args[0][0] == '-' {
+		return fmt.Errorf("%s", templatesInitUsage())
+	}
+	flag := args[0]
+
+	kindName := "parameterized-single-value"
+	name := ""
+	description := ""
+	queryTemplate := ""
+	flagShort := ""
+	force := false
+
+	rest := args[1:]
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case "--kind":
+			if i+1 >= len(rest) {
+				return fmt.Errorf("--kind requires a value")
+			}
+			kindName = rest[i+1]
+			i++
+		case "--name":
+			if i+1 >= len(rest) {
+				return fmt.Errorf("--name requires a value")
+			}
+			name = rest[i+1]
+			i++
+		case "--description":
+			if i+1 >= len(rest) {
+				return fmt.Errorf("--description requires a value")
+			}
+			description = rest[i+1]
+			i++
+		case "--query-template":
+			if i+1 >= len(rest) {
+				return fmt.Errorf("--query-template requires a value")
+			}
+			queryTemplate = rest[i+1]
+			i++
+		case "--flag-short":
+			if i+1 >= len(rest) {
+				return fmt.Errorf("--flag-short requires a value")
+			}
+			flagShort = rest[i+1]
+			i++
+		case "--force":
+			force = true
+		default:
+			return fmt.Errorf("unknown flag %q\n%s", rest[i], templatesInitUsage())
+		}
+	}
+
+	kind, ok := templateKinds[kindName]
+	if !ok {
+		return fmt.Errorf("unknown --kind %q\n%s", kindName, templatesInitUsage())
+	}
+
+	if name == "" {
+		name = flag
+	}
+	if description == "" {
+		description = kind.description
+	}
+	if queryTemplate == "" {
+		queryTemplate = kind.queryTemplate
+	}
+
+	tpl := search.QueryTemplate{
+		Name:             name,
+		Flag:             flag,
+		FlagShort:        flagShort,
+		Description:      description,
+		QueryTemplate:    queryTemplate,
+		Parameterized:    kind.parameterized,
+		SupportsMultiple: kind.multiple,
+	}
+
+	dir := userTemplateDir()
+	if dir == "" {
+		return fmt.Errorf("failed to resolve the user template directory")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, flag+".yaml")
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s already exists; pass --force to overwrite", path)
+	}
+
+	content, err := yaml.Marshal(tpl)
+	if err != nil {
+		return fmt.Errorf("failed to render template YAML: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if issues := search.ValidateTemplateFile(path); len(issues) > 0 {
+		os.Remove(path)
+		for _, issue := range issues {
+			fmt.Fprintln(os.Stderr, issue.String())
+		}
+		return fmt.Errorf("generated template failed validation, not written")
+	}
+
+	fmt.Printf("Wrote %s\n", path)
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunTemplatesInit_WritesValidTemplate(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+
+	if err := runTemplatesInit([]string{"my-author", "--kind", "author-filter"}); err != nil {
+		t.Fatalf("runTemplatesInit failed: %v", err)
+	}
+
+	path := filepath.Join(configDir, "autoprat", "templates", "my-author.yaml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected template file to be written: %v", err)
+	}
+	if !strings.Contains(string(content), "flag: my-author") {
+		t.Errorf("expected written YAML to contain the flag, got:\n%s", content)
+	}
+}
+
+func TestRunTemplatesInit_RefusesToOverwriteWithoutForce(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+
+	if err := runTemplatesInit([]string{"dup", "--kind", "label-filter"}); err != nil {
+		t.Fatalf("first init failed: %v", err)
+	}
+	if err := runTemplatesInit([]string{"dup", "--kind", "label-filter"}); err == nil {
+		t.Fatal("expected second init to fail without --force")
+	}
+	if err := runTemplatesInit([]string{"dup", "--kind", "label-filter", "--force"}); err != nil {
+		t.Fatalf("expected --force to allow overwrite, got: %v", err)
+	}
+}
+
+func TestRunTemplatesInit_UnknownKind(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := runTemplatesInit([]string{"my-flag", "--kind", "bogus"}); err == nil {
+		t.Fatal("expected an error for an unknown --kind")
+	}
+}
+
+func TestRunTemplatesInit_RequiresFlagName(t *testing.T) {
+	if err := runTemplatesInit(nil); err == nil {
+		t.Fatal("expected an error when no flag name is given")
+	}
+}
@@ -26,7 +26,16 @@ type PullRequest struct {
 	State             string
 	StatusCheckRollup StatusCheckRollup
 	Comments          []Comment
-	repo              string
+
+	// Mergeable, MergeStateStatus, IsInMergeQueue, and
+	// IsMergeQueueEnabled mirror the corresponding GraphQL PullRequest
+	// fields and feed MergeState/OverallStatus.
+	Mergeable           string
+	MergeStateStatus    string
+	IsInMergeQueue      bool
+	IsMergeQueueEnabled bool
+
+	repo string
 }
 
 type StatusCheckRollup struct {
@@ -52,13 +61,9 @@ type Comment struct {
 	} `json:"author"`
 }
 
-// LastCommentTime returns when any comment was last posted on the PR.
-func (pr PullRequest) LastCommentTime() string {
-	if len(pr.Comments) == 0 {
-		return "never"
-	}
-
-	// Find the most recent comment (any comment).
+// lastCommentAt returns the time of the most recent comment on pr, and
+// whether pr has any comments at all.
+func lastCommentAt(pr PullRequest) (time.Time, bool) {
 	var mostRecent time.Time
 	found := false
 
@@ -73,6 +78,12 @@ func (pr PullRequest) LastCommentTime() string {
 		}
 	}
 
+	return mostRecent, found
+}
+
+// LastCommentTime returns when any comment was last posted on the PR.
+func (pr PullRequest) LastCommentTime() string {
+	mostRecent, found := lastCommentAt(pr)
 	if !found {
 		return "never"
 	}
@@ -113,6 +124,56 @@ func (pr PullRequest) CIStatus() string {
 	return "Passing"
 }
 
+// MergeState summarises pr's mergeability as a single word: InQueue,
+// Clean, Blocked, Behind, Dirty, Draft, Unstable, HasHooks, or Unknown. It
+// prioritises IsInMergeQueue over the raw MergeStateStatus, since a PR
+// already queued for merge is no longer waiting on anything else.
+func (pr PullRequest) MergeState() string {
+	if pr.IsInMergeQueue {
+		return "InQueue"
+	}
+	switch pr.MergeStateStatus {
+	case "CLEAN":
+		return "Clean"
+	case "BLOCKED":
+		return "Blocked"
+	case "BEHIND":
+		return "Behind"
+	case "DIRTY":
+		return "Dirty"
+	case "DRAFT":
+		return "Draft"
+	case "UNSTABLE":
+		return "Unstable"
+	case "HAS_HOOKS":
+		return "HasHooks"
+	default:
+		return "Unknown"
+	}
+}
+
+// OverallStatus combines CIStatus with MergeState so callers can tell
+// "checks passing but blocked by review" apart from "in merge queue"
+// and "unmergeable", instead of acting on green checks alone.
+func (pr PullRequest) OverallStatus() string {
+	if pr.IsInMergeQueue {
+		return "InQueue"
+	}
+
+	if ci := pr.CIStatus(); ci != "Passing" {
+		return ci
+	}
+
+	switch mergeState := pr.MergeState(); mergeState {
+	case "Clean":
+		return "Ready"
+	case "Unknown":
+		return "Passing"
+	default:
+		return mergeState
+	}
+}
+
 // Author returns the author name for display purposes.
 // For bots, shows the full "app/botname" format to match search expectations.
 func (pr PullRequest) Author() string {
@@ -131,9 +192,66 @@ func (pr PullRequest) SearchAuthorName() string {
 	return pr.AuthorLogin
 }
 
+// FailingChecks returns the names of checks currently reporting
+// failure or requiring action.
+func (pr PullRequest) FailingChecks() []string {
+	var failing []string
+	for _, check := range pr.StatusCheckRollup.Contexts.Nodes {
+		status := check.State
+		if status == "" {
+			status = check.Conclusion
+		}
+		if status != "FAILURE" && status != "ACTION_REQUIRED" {
+			continue
+		}
+		name := check.Name
+		if name == "" {
+			name = check.Context
+		}
+		failing = append(failing, name)
+	}
+	return failing
+}
+
+// CommentCount returns how many comments have been posted on the PR.
+func (pr PullRequest) CommentCount() int {
+	return len(pr.Comments)
+}
+
+// LastCommentedBy returns the login of whoever posted the most recent
+// comment, or "" if the PR has no comments.
+func (pr PullRequest) LastCommentedBy() string {
+	var author string
+	var latest time.Time
+	found := false
+
+	for _, comment := range pr.Comments {
+		createdAt, err := time.Parse(time.RFC3339, comment.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if !found || createdAt.After(latest) {
+			latest = createdAt
+			author = comment.Author.Login
+			found = true
+		}
+	}
+
+	return author
+}
+
+// Age returns how long ago the PR was created.
+func (pr PullRequest) Age() time.Duration {
+	createdAt, err := time.Parse(time.RFC3339, pr.CreatedAt)
+	if err != nil {
+		return 0
+	}
+	return time.Since(createdAt)
+}
+
 // PrintThrottleDiagnostics shows what the throttling logic would do for debugging.
 func (pr PullRequest) PrintThrottleDiagnostics(allActions []Action, throttle time.Duration) {
-	toPost := FilterActions(allActions, pr.Labels)
+	toPost := FilterActions(allActions, pr)
 	if len(toPost) == 0 {
 		return
 	}
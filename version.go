@@ -1,41 +1,163 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"runtime"
 	"runtime/debug"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
-// Info contains version and build information.
+// ModuleInfo mirrors the subset of debug.Module autoprat surfaces for
+// provenance auditing: which module versions (and any replace
+// directives) were compiled into this binary.
+type ModuleInfo struct {
+	Path    string      `json:"path" yaml:"path"`
+	Version string      `json:"version" yaml:"version"`
+	Sum     string      `json:"sum,omitempty" yaml:"sum,omitempty"`
+	Replace *ModuleInfo `json:"replace,omitempty" yaml:"replace,omitempty"`
+}
+
+// Info contains version and build information. Field names and JSON
+// casing are a stable contract for `--version-format json/yaml`
+// consumers; don't rename or re-case them without a compatibility
+// note.
 type Info struct {
-	Version   string
-	BuildTime string
-	GoVersion string
-	Platform  string
+	Version   string `json:"version" yaml:"version"`
+	BuildTime string `json:"build_time" yaml:"build_time"`
+	GoVersion string `json:"go_version" yaml:"go_version"`
+	Platform  string `json:"platform" yaml:"platform"`
+
+	// VCSRevision, VCSTime, and VCSModified are populated from the
+	// "vcs.revision", "vcs.time", and "vcs.modified" build settings
+	// debug.ReadBuildInfo() embeds for binaries built from a VCS
+	// checkout (e.g. a plain `go build` with no ldflags). VCSModified
+	// is true when the working tree had uncommitted changes at build
+	// time.
+	VCSRevision string `json:"vcs_revision,omitempty" yaml:"vcs_revision,omitempty"`
+	VCSTime     string `json:"vcs_time,omitempty" yaml:"vcs_time,omitempty"`
+	VCSModified bool   `json:"vcs_modified" yaml:"vcs_modified"`
+
+	// MainModulePath and MainModuleVersion identify the module this
+	// binary was built from.
+	MainModulePath    string `json:"main_module_path,omitempty" yaml:"main_module_path,omitempty"`
+	MainModuleVersion string `json:"main_module_version,omitempty" yaml:"main_module_version,omitempty"`
+
+	// Dependencies lists every module compiled into this binary, for
+	// auditing which versions shipped, the same surface
+	// debug/buildinfo.ReadFile exposes for an on-disk binary.
+	Dependencies []ModuleInfo `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 }
 
-// Get returns the current version information.
+// Get returns the current version information, all of it recovered
+// from debug.ReadBuildInfo(): Version is the main module version and
+// BuildTime the vcs.time setting, each "unknown" when the build info
+// doesn't supply it (e.g. a "(devel)" build).
 func Get() Info {
 	buildVersion := "unknown"
 	buildTime := "unknown"
 	goVer := runtime.Version()
 
-	if info, ok := debug.ReadBuildInfo(); ok {
-		if info.Main.Version != "(devel)" && info.Main.Version != "" {
-			buildVersion = info.Main.Version
+	info := Info{
+		GoVersion: goVer,
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if bi.Main.Version != "(devel)" && bi.Main.Version != "" {
+			buildVersion = bi.Main.Version
 		}
 
-		for _, setting := range info.Settings {
+		for _, setting := range bi.Settings {
 			switch setting.Key {
 			case "vcs.time":
 				buildTime = setting.Value
+				info.VCSTime = setting.Value
+			case "vcs.revision":
+				info.VCSRevision = setting.Value
+			case "vcs.modified":
+				info.VCSModified = setting.Value == "true"
 			}
 		}
+
+		info.MainModulePath = bi.Main.Path
+		info.MainModuleVersion = bi.Main.Version
+
+		info.Dependencies = make([]ModuleInfo, 0, len(bi.Deps))
+		for _, dep := range bi.Deps {
+			info.Dependencies = append(info.Dependencies, toModuleInfo(dep))
+		}
 	}
 
-	return Info{
-		Version:   buildVersion,
-		BuildTime: buildTime,
-		GoVersion: goVer,
-		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	info.Version = buildVersion
+	info.BuildTime = buildTime
+	return info
+}
+
+// String renders i as the same multi-line, human-readable text printed
+// by `autoprat --version`.
+func (i Info) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "autoprat version %s\n", i.Version)
+	fmt.Fprintf(&b, "Built: %s\n", i.BuildTime)
+	fmt.Fprintf(&b, "Go version: %s\n", i.GoVersion)
+	fmt.Fprintf(&b, "Platform: %s\n", i.Platform)
+	if i.VCSRevision != "" {
+		modified := ""
+		if i.VCSModified {
+			modified = " (modified)"
+		}
+		fmt.Fprintf(&b, "VCS revision: %s%s\n", i.VCSRevision, modified)
+	}
+	if i.MainModulePath != "" {
+		fmt.Fprintf(&b, "Module: %s@%s\n", i.MainModulePath, i.MainModuleVersion)
+	}
+	return b.String()
+}
+
+// MarshalJSON implements json.Marshaler. It exists (rather than
+// relying solely on the struct tags above) to make Info's JSON shape
+// an explicit, tested API contract for --version-format json
+// consumers.
+func (i Info) MarshalJSON() ([]byte, error) {
+	type infoJSON Info
+	return json.Marshal(infoJSON(i))
+}
+
+// Format writes i to w in the requested format: "text" (or "", the
+// same rendering as String()), "json", or "yaml".
+func (i Info) Format(w io.Writer, format string) error {
+	switch format {
+	case "", "text":
+		_, err := io.WriteString(w, i.String())
+		return err
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(i)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		defer enc.Close()
+		return enc.Encode(i)
+	default:
+		return fmt.Errorf("unknown version format %q, expected text, json, or yaml", format)
+	}
+}
+
+// toModuleInfo converts a debug.Module (and its Replace, if any) into
+// a ModuleInfo.
+func toModuleInfo(m *debug.Module) ModuleInfo {
+	mi := ModuleInfo{
+		Path:    m.Path,
+		Version: m.Version,
+		Sum:     m.Sum,
+	}
+	if m.Replace != nil {
+		replace := toModuleInfo(m.Replace)
+		mi.Replace = &replace
 	}
+	return mi
 }
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestInfo_String(t *testing.T) {
+	info := Info{Version: "v1.2.3", BuildTime: "2024-01-01T00:00:00Z", GoVersion: "go1.21.0", Platform: "linux/amd64"}
+	s := info.String()
+	for _, want := range []string{"autoprat version v1.2.3", "Built: 2024-01-01T00:00:00Z", "Go version: go1.21.0", "Platform: linux/amd64"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("String() = %q, want it to contain %q", s, want)
+		}
+	}
+}
+
+func TestInfo_MarshalJSON_FieldNames(t *testing.T) {
+	info := Info{
+		Version:           "v1.2.3",
+		BuildTime:         "2024-01-01T00:00:00Z",
+		GoVersion:         "go1.21.0",
+		Platform:          "linux/amd64",
+		VCSRevision:       "deadbeef",
+		MainModulePath:    "example.com/mymodule",
+		MainModuleVersion: "(devel)",
+		Dependencies:      []ModuleInfo{{Path: "example.com/dep", Version: "v1.0.0"}},
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	for _, field := range []string{"version", "build_time", "go_version", "platform", "vcs_revision", "main_module_path", "main_module_version", "dependencies"} {
+		if _, ok := m[field]; !ok {
+			t.Errorf("marshaled JSON missing field %q: %s", field, data)
+		}
+	}
+
+	// vcs_time and vcs_modified are omitempty/zero-valued here, so
+	// vcs_time should be absent but vcs_modified (a bool, not
+	// omitempty) should still be present as false.
+	if _, ok := m["vcs_time"]; ok {
+		t.Errorf("expected empty vcs_time to be omitted: %s", data)
+	}
+	if v, ok := m["vcs_modified"]; !ok || v != false {
+		t.Errorf("expected vcs_modified=false to be present: %s", data)
+	}
+}
+
+func TestInfo_MarshalJSON_RoundTrip(t *testing.T) {
+	original := Info{
+		Version:   "v1.2.3",
+		BuildTime: "2024-01-01T00:00:00Z",
+		GoVersion: "go1.21.0",
+		Platform:  "linux/amd64",
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	var decoded Info
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("round-tripped Info = %+v, want %+v", decoded, original)
+	}
+}
+
+func TestInfo_Format(t *testing.T) {
+	info := Info{Version: "v1.2.3", BuildTime: "2024-01-01T00:00:00Z", GoVersion: "go1.21.0", Platform: "linux/amd64"}
+
+	tests := []struct {
+		format string
+	}{
+		{""}, {"text"}, {"json"}, {"yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := info.Format(&buf, tt.format); err != nil {
+				t.Fatalf("Format(%q) failed: %v", tt.format, err)
+			}
+			if buf.Len() == 0 {
+				t.Errorf("Format(%q) produced no output", tt.format)
+			}
+		})
+	}
+}
+
+func TestInfo_Format_JSONMatchesMarshalJSON(t *testing.T) {
+	info := Info{Version: "v1.2.3", BuildTime: "2024-01-01T00:00:00Z", GoVersion: "go1.21.0", Platform: "linux/amd64"}
+
+	var buf bytes.Buffer
+	if err := info.Format(&buf, "json"); err != nil {
+		t.Fatalf("Format(json) failed: %v", err)
+	}
+
+	var decoded Info
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(Format output) failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, info) {
+		t.Errorf("Format(json) output decoded to %+v, want %+v", decoded, info)
+	}
+}
+
+func TestInfo_Format_YAML(t *testing.T) {
+	info := Info{Version: "v1.2.3", BuildTime: "2024-01-01T00:00:00Z", GoVersion: "go1.21.0", Platform: "linux/amd64"}
+
+	var buf bytes.Buffer
+	if err := info.Format(&buf, "yaml"); err != nil {
+		t.Fatalf("Format(yaml) failed: %v", err)
+	}
+
+	var decoded Info
+	if err := yaml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("yaml.Unmarshal(Format output) failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, info) {
+		t.Errorf("Format(yaml) output decoded to %+v, want %+v", decoded, info)
+	}
+}
+
+func TestInfo_Format_UnknownFormat(t *testing.T) {
+	info := Info{}
+	var buf bytes.Buffer
+	if err := info.Format(&buf, "toml"); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"runtime"
+	"runtime/debug"
 	"strings"
 	"testing"
 )
@@ -47,6 +48,14 @@ func TestGet(t *testing.T) {
 	if info.GoVersion != runtime.Version() {
 		t.Errorf("GoVersion should be %s, got: %s", runtime.Version(), info.GoVersion)
 	}
+
+	// When debug.ReadBuildInfo succeeds (true for any `go test` binary),
+	// the main module path should be populated too.
+	if _, ok := debug.ReadBuildInfo(); ok {
+		if info.MainModulePath == "" {
+			t.Error("MainModulePath should not be empty when ReadBuildInfo succeeds")
+		}
+	}
 }
 
 func TestInfoStruct(t *testing.T) {
@@ -75,6 +84,34 @@ func TestInfoStruct(t *testing.T) {
 	}
 }
 
+func TestInfoStruct_VCSAndDependencies(t *testing.T) {
+	dep := ModuleInfo{Path: "example.com/dep", Version: "v1.2.3", Sum: "h1:abc="}
+	info := Info{
+		VCSRevision:       "deadbeef",
+		VCSTime:           "2024-01-01T00:00:00Z",
+		VCSModified:       true,
+		MainModulePath:    "example.com/mymodule",
+		MainModuleVersion: "(devel)",
+		Dependencies:      []ModuleInfo{dep},
+	}
+
+	if info.VCSRevision != "deadbeef" {
+		t.Errorf("Expected VCSRevision deadbeef, got %s", info.VCSRevision)
+	}
+
+	if !info.VCSModified {
+		t.Error("Expected VCSModified to be true")
+	}
+
+	if info.MainModulePath != "example.com/mymodule" {
+		t.Errorf("Expected MainModulePath example.com/mymodule, got %s", info.MainModulePath)
+	}
+
+	if len(info.Dependencies) != 1 || info.Dependencies[0].Path != "example.com/dep" {
+		t.Errorf("Expected a single dependency example.com/dep, got %v", info.Dependencies)
+	}
+}
+
 func TestGetConsistency(t *testing.T) {
 	// Test that multiple calls return consistent results
 	info1 := Get()
@@ -96,3 +133,46 @@ func TestGetConsistency(t *testing.T) {
 		t.Error("Platform should be consistent across multiple calls")
 	}
 }
+
+// TestGetBuildInfoConsistency cross-checks Get()'s VCS/module fields
+// against what debug.ReadBuildInfo() reports directly for the current
+// test binary, so a future refactor of Get() can't silently drift from
+// its one source of truth.
+func TestGetBuildInfoConsistency(t *testing.T) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		t.Skip("debug.ReadBuildInfo() not available for this test binary")
+	}
+
+	info := Get()
+
+	if info.MainModulePath != bi.Main.Path {
+		t.Errorf("MainModulePath = %q, want %q", info.MainModulePath, bi.Main.Path)
+	}
+
+	if info.MainModuleVersion != bi.Main.Version {
+		t.Errorf("MainModuleVersion = %q, want %q", info.MainModuleVersion, bi.Main.Version)
+	}
+
+	if len(info.Dependencies) != len(bi.Deps) {
+		t.Fatalf("len(Dependencies) = %d, want %d", len(info.Dependencies), len(bi.Deps))
+	}
+	for i, dep := range bi.Deps {
+		if info.Dependencies[i].Path != dep.Path || info.Dependencies[i].Version != dep.Version {
+			t.Errorf("Dependencies[%d] = %+v, want path=%q version=%q", i, info.Dependencies[i], dep.Path, dep.Version)
+		}
+	}
+
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.VCSRevision != setting.Value {
+				t.Errorf("VCSRevision = %q, want %q", info.VCSRevision, setting.Value)
+			}
+		case "vcs.modified":
+			if info.VCSModified != (setting.Value == "true") {
+				t.Errorf("VCSModified = %v, want %v", info.VCSModified, setting.Value == "true")
+			}
+		}
+	}
+}
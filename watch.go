@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// WatchState records when each (PR, action) pair was last posted, so
+// RunWatch only re-emits a match once its cooldown has elapsed instead
+// of on every poll.
+type WatchState map[string]time.Time
+
+// LoadWatchState reads a WatchState from path, returning an empty
+// state if the file doesn't exist yet.
+func LoadWatchState(path string) (WatchState, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return WatchState{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch state %s: %w", path, err)
+	}
+
+	var state WatchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse watch state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// Save writes state to path as JSON, creating its parent directory if
+// needed.
+func (s WatchState) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// watchKey identifies one (PR, action) pair in WatchState.
+func watchKey(repo string, number int, comment string) string {
+	return fmt.Sprintf("%s#%d#%s", repo, number, comment)
+}
+
+// defaultWatchStateFile returns where RunWatch persists its state when
+// --state-file isn't given: $XDG_STATE_HOME/autoprat/state.json, or
+// $HOME/.local/state/autoprat/state.json if unset.
+func defaultWatchStateFile() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "autoprat", "state.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "state", "autoprat", "state.json")
+}
+
+// WatchEvent is one state transition emitted by RunWatch: a match that
+// wasn't suppressed by its action's cooldown.
+type WatchEvent struct {
+	Time       time.Time `json:"time"`
+	Repository string    `json:"repository"`
+	PR         int       `json:"pr"`
+	Comment    string    `json:"comment"`
+	Command    string    `json:"command"`
+	Executed   bool      `json:"executed"`
+}
+
+// RunWatch polls the same fetch/filter pipeline as Run on
+// config.WatchInterval, diffing each cycle's matched actions against a
+// WatchState persisted at config.StateFile so only new matches (or
+// matches whose action Cooldown, or Config.Throttle if the action
+// didn't set one, has elapsed) are emitted. Emitted events are executed
+// by exec'ing gh(1) directly (no shell) when config.Execute is set, and
+// are always reported:
+// as one JSON object per event when config.Output is "json" or
+// "ndjson", or as a plain "repo#pr: command" line otherwise.
+func RunWatch(ctx context.Context, config *Config, clientFactory func(repo string) (GitHubClient, error)) error {
+	statePath := config.StateFile
+	if statePath == "" {
+		statePath = defaultWatchStateFile()
+	}
+	if statePath == "" {
+		return fmt.Errorf("failed to resolve a watch state file path; pass --state-file explicitly")
+	}
+
+	state, err := LoadWatchState(statePath)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(config.WatchInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := runWatchCycle(ctx, config, clientFactory, state, statePath); err != nil {
+			fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runWatchCycle runs one fetch/filter/diff pass, posting and persisting
+// any new matches it finds.
+func runWatchCycle(ctx context.Context, config *Config, clientFactory func(repo string) (GitHubClient, error), state WatchState, statePath string) error {
+	allRepositoryPRs, err := fetchAllRepositoryPRsWithSearch(ctx, config.Repositories, config.SearchQuery, clientFactory, config)
+	if err != nil {
+		if !config.PartialResults || allRepositoryPRs == nil {
+			return fmt.Errorf("failed to fetch PRs: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: some repositories failed to fetch, continuing with partial results: %v\n", err)
+	}
+
+	filteredPRs := applyPRFiltering(allRepositoryPRs, config)
+
+	changed := false
+	for _, repoPRs := range filteredPRs {
+		for _, prItem := range repoPRs.PRs {
+			for _, a := range FilterActions(config.Actions, prItem) {
+				comment, err := a.Render(prItem, repoPRs.Repository)
+				if err != nil {
+					return fmt.Errorf("failed to render comment for %s#%d: %w", repoPRs.Repository, prItem.Number, err)
+				}
+
+				cooldown := a.Cooldown
+				if cooldown == 0 {
+					cooldown = config.Throttle
+				}
+
+				key := watchKey(repoPRs.Repository, prItem.Number, comment)
+				if last, ok := state[key]; ok && cooldown > 0 && time.Since(last) < cooldown {
+					continue
+				}
+
+				event := WatchEvent{
+					Time:       time.Now(),
+					Repository: repoPRs.Repository,
+					PR:         prItem.Number,
+					Comment:    comment,
+					Command:    a.CommandWithComment(repoPRs.Repository, prItem.Number, comment),
+				}
+
+				if config.Execute {
+					args := a.ArgsWithComment(repoPRs.Repository, prItem.Number, comment)
+					if err := executeGH(args); err != nil {
+						fmt.Fprintf(os.Stderr, "watch: failed to run %q: %v\n", event.Command, err)
+						continue
+					}
+					event.Executed = true
+				}
+
+				emitWatchEvent(event, config.Output)
+
+				state[key] = event.Time
+				changed = true
+			}
+		}
+	}
+
+	if changed {
+		return state.Save(statePath)
+	}
+	return nil
+}
+
+func emitWatchEvent(event WatchEvent, output string) {
+	if output == "json" || output == "ndjson" {
+		data, err := json.Marshal(event)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "watch: failed to encode event: %v\n", err)
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("%s#%d: %s\n", event.Repository, event.PR, event.Command)
+}
+
+// executeGH runs gh(1) with args directly via exec.Command, not a
+// shell, so PR titles/authors/branch names rendered into a comment
+// can't be interpreted as shell syntax.
+func executeGH(args []string) error {
+	cmd := exec.Command("gh", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
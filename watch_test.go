@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchState_SaveAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	state := WatchState{
+		watchKey("owner/repo", 1, "/approve"): time.Now().Truncate(time.Second),
+	}
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadWatchState(path)
+	if err != nil {
+		t.Fatalf("LoadWatchState failed: %v", err)
+	}
+
+	key := watchKey("owner/repo", 1, "/approve")
+	if !loaded[key].Equal(state[key]) {
+		t.Errorf("expected loaded state to match saved state, got %v want %v", loaded[key], state[key])
+	}
+}
+
+func TestLoadWatchState_MissingFileReturnsEmpty(t *testing.T) {
+	state, err := LoadWatchState(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if err != nil {
+		t.Fatalf("expected no error for a missing state file, got %v", err)
+	}
+	if len(state) != 0 {
+		t.Errorf("expected an empty state, got %v", state)
+	}
+}
+
+func TestRunWatchCycle_SecondPassIsSuppressedByCooldown(t *testing.T) {
+	ctx := context.Background()
+
+	pr := PullRequest{Number: 1, Labels: []string{"lgtm"}}
+	client := &fakeWatchClient{prs: []PullRequest{pr}}
+
+	config := &Config{
+		Repositories: []string{"owner/repo"},
+		Actions:      []Action{{Comment: "/approve", Cooldown: time.Hour}},
+	}
+
+	factory := func(repo string) (GitHubClient, error) { return client, nil }
+	state := WatchState{}
+	statePath := filepath.Join(t.TempDir(), "state.json")
+
+	if err := runWatchCycle(ctx, config, factory, state, statePath); err != nil {
+		t.Fatalf("first cycle failed: %v", err)
+	}
+	if len(state) != 1 {
+		t.Fatalf("expected 1 state entry after the first cycle, got %d", len(state))
+	}
+
+	firstSeen := state[watchKey("owner/repo", 1, "/approve")]
+
+	if err := runWatchCycle(ctx, config, factory, state, statePath); err != nil {
+		t.Fatalf("second cycle failed: %v", err)
+	}
+	if got := state[watchKey("owner/repo", 1, "/approve")]; !got.Equal(firstSeen) {
+		t.Errorf("expected the cooldown to suppress a re-post, but the state entry changed: %v -> %v", firstSeen, got)
+	}
+}
+
+type fakeWatchClient struct {
+	prs []PullRequest
+}
+
+func (c *fakeWatchClient) Search(ctx context.Context, query string) ([]PullRequest, error) {
+	return c.prs, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// StepDefinition describes one step of a multi-step workflow: a
+// comment to post and an optional label gate it must wait for before
+// the step is considered ready.
+type StepDefinition struct {
+	Comment      string `yaml:"comment"`
+	WaitForLabel string `yaml:"wait_for_label,omitempty"`
+}
+
+// WorkflowStep is the runtime counterpart of StepDefinition, bound to
+// the flag of the ActionDefinition it originated from.
+type WorkflowStep struct {
+	Flag         string
+	Comment      string
+	WaitForLabel string
+}
+
+// StepOutcome records what happened to a single workflow step for one
+// PR: either it ran, or it's pending along with why.
+type StepOutcome struct {
+	Flag    string
+	Comment string
+	Ran     bool
+	Reason  string // populated when Ran is false
+}
+
+// WorkflowResult is the Result variant produced when any selected
+// action declares a multi-step workflow. It lists, per PR, which
+// steps ran, which are pending, and why, so the plan can be shown
+// without posting anything.
+type WorkflowResult struct {
+	Repository string
+	PRNumber   int
+	Steps      []StepOutcome
+}
+
+// OrderWorkflowSteps topologically sorts actionDefs by depends_on
+// (each entry referencing another action's Flag) and flattens their
+// Steps into a single ordered list. An error is returned if
+// depends_on references an unknown flag or forms a cycle.
+func OrderWorkflowSteps(actionDefs []ActionDefinition) ([]WorkflowStep, error) {
+	byFlag := make(map[string]ActionDefinition, len(actionDefs))
+	for _, ad := range actionDefs {
+		byFlag[ad.Flag] = ad
+	}
+
+	const (
+		unvisited = 0
+		visiting  = 1
+		done      = 2
+	)
+	state := make(map[string]int)
+	var ordered []ActionDefinition
+
+	var visit func(flag string) error
+	visit = func(flag string) error {
+		switch state[flag] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("cycle detected in depends_on involving %q", flag)
+		}
+
+		ad, ok := byFlag[flag]
+		if !ok {
+			return fmt.Errorf("depends_on references unknown action %q", flag)
+		}
+
+		state[flag] = visiting
+		for _, dep := range ad.DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[flag] = done
+		ordered = append(ordered, ad)
+		return nil
+	}
+
+	for _, ad := range actionDefs {
+		if err := visit(ad.Flag); err != nil {
+			return nil, err
+		}
+	}
+
+	var steps []WorkflowStep
+	for _, ad := range ordered {
+		for _, s := range ad.Steps {
+			steps = append(steps, WorkflowStep{
+				Flag:         ad.Flag,
+				Comment:      s.Comment,
+				WaitForLabel: s.WaitForLabel,
+			})
+		}
+	}
+	return steps, nil
+}
+
+// PlanWorkflow decides, for a single PR, which ordered steps are
+// ready to post and which are pending: a step whose WaitForLabel
+// hasn't appeared on the PR yet is left for the next invocation,
+// and a step whose comment was already posted within throttle is
+// skipped as a duplicate.
+func PlanWorkflow(steps []WorkflowStep, pr PullRequest, throttle time.Duration) []StepOutcome {
+	outcomes := make([]StepOutcome, 0, len(steps))
+
+	for _, step := range steps {
+		if step.WaitForLabel != "" && !contains(pr.Labels, step.WaitForLabel) {
+			outcomes = append(outcomes, StepOutcome{
+				Flag:    step.Flag,
+				Comment: step.Comment,
+				Reason:  fmt.Sprintf("waiting for label %q", step.WaitForLabel),
+			})
+			continue
+		}
+
+		if throttle > 0 && HasRecentComment(pr, step.Comment, throttle) {
+			outcomes = append(outcomes, StepOutcome{
+				Flag:    step.Flag,
+				Comment: step.Comment,
+				Reason:  "throttled: identical comment posted recently",
+			})
+			continue
+		}
+
+		outcomes = append(outcomes, StepOutcome{
+			Flag:    step.Flag,
+			Comment: step.Comment,
+			Ran:     true,
+		})
+	}
+
+	return outcomes
+}
@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+func TestOrderWorkflowSteps(t *testing.T) {
+	defs := []ActionDefinition{
+		{
+			Flag: "lgtm",
+			Steps: []StepDefinition{
+				{Comment: "/lgtm"},
+			},
+			DependsOn: []string{"ok-to-test"},
+		},
+		{
+			Flag: "ok-to-test",
+			Steps: []StepDefinition{
+				{Comment: "/label needs-ok-to-test"},
+			},
+		},
+	}
+
+	steps, err := OrderWorkflowSteps(defs)
+	if err != nil {
+		t.Fatalf("OrderWorkflowSteps failed: %v", err)
+	}
+
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(steps))
+	}
+	if steps[0].Flag != "ok-to-test" || steps[1].Flag != "lgtm" {
+		t.Errorf("expected ok-to-test before lgtm, got %q then %q", steps[0].Flag, steps[1].Flag)
+	}
+}
+
+func TestOrderWorkflowStepsCycle(t *testing.T) {
+	defs := []ActionDefinition{
+		{Flag: "a", Steps: []StepDefinition{{Comment: "/a"}}, DependsOn: []string{"b"}},
+		{Flag: "b", Steps: []StepDefinition{{Comment: "/b"}}, DependsOn: []string{"a"}},
+	}
+
+	if _, err := OrderWorkflowSteps(defs); err == nil {
+		t.Fatal("expected cycle error, got nil")
+	}
+}
+
+func TestPlanWorkflow(t *testing.T) {
+	steps := []WorkflowStep{
+		{Flag: "ok-to-test", Comment: "/label needs-ok-to-test"},
+		{Flag: "approve", Comment: "/approve", WaitForLabel: "ok-to-test"},
+		{Flag: "lgtm", Comment: "/lgtm", WaitForLabel: "approved"},
+	}
+
+	pr := PullRequest{Labels: []string{"ok-to-test"}}
+
+	outcomes := PlanWorkflow(steps, pr, 0)
+	if len(outcomes) != 3 {
+		t.Fatalf("expected 3 outcomes, got %d", len(outcomes))
+	}
+	if !outcomes[0].Ran {
+		t.Errorf("expected first step to run, got pending: %s", outcomes[0].Reason)
+	}
+	if !outcomes[1].Ran {
+		t.Errorf("expected second step to run since ok-to-test label is present, got pending: %s", outcomes[1].Reason)
+	}
+	if outcomes[2].Ran {
+		t.Errorf("expected third step to be pending, awaiting approved label")
+	}
+}